@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"zatrano/pkg/scaffold"
+)
+
+const usage = `Kullanım:
+  go run cmd/cli/main.go make:resource <Ad> alan:tip[:seçenek...] ... [-force] [-label=Etiket] [-plural-label=Etiketler]
+
+Alan tipleri : string, text, int, uint, float, bool, date, datetime
+Alan seçenekleri: required, optional, unique, index, label=Etiket
+
+Örnek:
+  go run cmd/cli/main.go make:resource Product name:string:unique price:float stock:uint description:text active:bool -label=Ürün -plural-label=Ürünler`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Println(usage)
+		os.Exit(1)
+	}
+
+	switch os.Args[1] {
+	case "make:resource":
+		if err := makeResource(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, "Hata:", err)
+			os.Exit(1)
+		}
+	case "help", "-h", "--help":
+		fmt.Println(usage)
+	default:
+		fmt.Fprintf(os.Stderr, "Bilinmeyen komut: %s\n\n%s\n", os.Args[1], usage)
+		os.Exit(1)
+	}
+}
+
+func makeResource(args []string) error {
+	var opts scaffold.Options
+	var positional []string
+	for _, arg := range args {
+		switch {
+		case arg == "-force" || arg == "--force":
+			opts.Force = true
+		case strings.HasPrefix(arg, "-label=") || strings.HasPrefix(arg, "--label="):
+			opts.Label = arg[strings.Index(arg, "=")+1:]
+		case strings.HasPrefix(arg, "-plural-label=") || strings.HasPrefix(arg, "--plural-label="):
+			opts.PluralLabel = arg[strings.Index(arg, "=")+1:]
+		case strings.HasPrefix(arg, "-"):
+			return fmt.Errorf("bilinmeyen bayrak: %s", arg)
+		default:
+			positional = append(positional, arg)
+		}
+	}
+	if len(positional) == 0 {
+		return fmt.Errorf("kaynak adı belirtilmedi\n\n%s", usage)
+	}
+
+	res, err := scaffold.ParseResource(positional[0], positional[1:], opts)
+	if err != nil {
+		return err
+	}
+
+	written, err := scaffold.Generate(res, opts)
+	for _, path := range written {
+		fmt.Println("  oluşturuldu/güncellendi:", path)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("\n%s kaynağı hazır. Tabloyu oluşturmak için: go run database/cmd/main.go -up\n", res.Name)
+	fmt.Printf("Liste sayfası: %s\n", res.RoutePath)
+	return nil
+}
@@ -1,15 +1,24 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"zatrano/configs"
+	"zatrano/database"
+	"zatrano/middlewares"
+	"zatrano/pkg/domainerrors"
+	"zatrano/pkg/env"
 	"zatrano/pkg/flashmessages"
 	"zatrano/pkg/logs"
 	"zatrano/pkg/templatehelpers"
+	"zatrano/pkg/turkishsearch"
 	"zatrano/routes"
+	"zatrano/services"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/template/html/v2"
@@ -30,6 +39,14 @@ func main() {
 	configs.InitDB()
 	defer configs.CloseDB()
 
+	if configs.IsInMemoryDB() {
+		logs.SLog.Info("Bellek içi SQLite veritabanı kullanılıyor, migrasyonlar ve seeder'lar başlangıçta çalıştırılıyor.")
+		database.Initialize(configs.GetDB(), true, true)
+	}
+
+	turkishsearch.DetectExtensions(configs.GetDB())
+	services.InitSettings()
+
 	configs.InitSession()
 
 	engine := html.New("./views", ".html")
@@ -39,12 +56,13 @@ func main() {
 	app := fiber.New(fiber.Config{
 		Views: engine,
 		ErrorHandler: func(c *fiber.Ctx, err error) error {
-			code := fiber.StatusInternalServerError
-			message := "Internal Server Error"
+			code := domainerrors.HTTPStatus(err)
+			message := domainerrors.UserMessage(err)
 
-			if e, ok := err.(*fiber.Error); ok {
-				code = e.Code
-				message = e.Message
+			var fiberErr *fiber.Error
+			if errors.As(err, &fiberErr) {
+				code = fiberErr.Code
+				message = fiberErr.Message
 			}
 
 			logs.Log.Error("Fiber request error",
@@ -55,6 +73,10 @@ func main() {
 				zap.String("ip", c.IP()),
 			)
 
+			if domainerrors.IsUnavailable(err) {
+				return middlewares.RenderDatabaseUnavailable(c)
+			}
+
 			return c.Status(code).JSON(fiber.Map{"error": message})
 		},
 	})
@@ -63,10 +85,13 @@ func main() {
 	app.Use(configs.SetupCSRF())
 	routes.SetupRoutes(app, configs.GetDB())
 
-	startServer(app)
+	workers := services.NewJobWorkerPool()
+	workers.Start()
+
+	startServer(app, workers)
 }
 
-func startServer(app *fiber.App) {
+func startServer(app *fiber.App, workers *services.JobWorkerPool) {
 	shutdown := make(chan os.Signal, 1)
 	signal.Notify(shutdown, os.Interrupt, syscall.SIGTERM)
 
@@ -100,5 +125,11 @@ func startServer(app *fiber.App) {
 		logs.Log.Info("Sunucu başarıyla kapatıldı")
 	}
 
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(env.GetEnvAsInt("JOB_SHUTDOWN_TIMEOUT_SECONDS", 30))*time.Second)
+	defer cancel()
+	if err := workers.Stop(ctx); err != nil {
+		logs.Log.Error("İş çalışanları kapatılırken hata oluştu", zap.Error(err))
+	}
+
 	logs.Log.Info("Uygulama başarıyla sonlandırıldı.")
 }
@@ -1,13 +1,19 @@
 package configs
 
 import (
+	"fmt"
+	"net/url"
 	"os"
-	"strconv"
+	"strings"
 	"time"
 
+	"zatrano/pkg/dbhealth"
 	"zatrano/pkg/env"
 	"zatrano/pkg/logs"
+	"zatrano/pkg/replica"
 
+	"github.com/glebarez/sqlite"
+	"github.com/jackc/pgx/v5/stdlib"
 	"github.com/joho/godotenv"
 	"go.uber.org/zap"
 	"gorm.io/driver/postgres"
@@ -15,8 +21,15 @@ import (
 	"gorm.io/gorm/logger"
 )
 
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+)
+
 var DB *gorm.DB
 
+var connectAttemptTimeout = 5 * time.Second
+
 type DatabaseConfig struct {
 	Host     string
 	Port     int
@@ -25,6 +38,20 @@ type DatabaseConfig struct {
 	Name     string
 	SSLMode  string
 	TimeZone string
+
+	ApplicationName    string
+	StatementTimeout   time.Duration
+	SearchPath         []string
+	ConnectTimeout     time.Duration
+	SSLRootCert        string
+	SSLCert            string
+	SSLKey             string
+	SSLKeyPassword     string
+	StatementCacheMode string
+	StatementCacheSize int
+
+	Source string
+	Extra  url.Values
 }
 
 func InitDB() {
@@ -35,60 +62,36 @@ func InitDB() {
 		logs.SLog.Info(".env dosyası başarıyla yüklendi")
 	}
 
-	portStr := env.GetEnvWithDefault("DB_PORT", "5432")
-	port, err := strconv.Atoi(portStr)
-	if err != nil {
-		logs.SLog.Fatalw("Invalid DB_PORT environment variable",
-			"value", portStr,
-			"error", err,
-		)
-	}
-
-	dbConfig := DatabaseConfig{
-		Host:     env.GetEnvWithDefault("DB_HOST", "localhost"),
-		Port:     port,
-		User:     env.GetEnvWithDefault("DB_USERNAME", "postgres"),
-		Password: env.GetEnvWithDefault("DB_PASSWORD", ""),
-		Name:     env.GetEnvWithDefault("DB_DATABASE", "myapp"),
-		SSLMode:  env.GetEnvWithDefault("DB_SSL_MODE", "disable"),
-		TimeZone: env.GetEnvWithDefault("DB_TIMEZONE", "UTC"),
+	driver := env.GetEnvWithDefault("DB_DRIVER", DriverPostgres)
+	var dialector gorm.Dialector
+	switch driver {
+	case DriverPostgres:
+		dialector = postgresDialector()
+	case DriverSQLite:
+		dialector = sqliteDialector()
+	default:
+		logs.SLog.Fatalw("Desteklenmeyen DB_DRIVER değeri (postgres veya sqlite olmalı)", "value", driver)
 	}
 
-	logs.Log.Info("Database configuration loaded",
-		zap.String("host", dbConfig.Host),
-		zap.Int("port", dbConfig.Port),
-		zap.String("user", dbConfig.User),
-		zap.String("database", dbConfig.Name),
-		zap.String("sslmode", dbConfig.SSLMode),
-		zap.String("timezone", dbConfig.TimeZone),
-	)
-
-	dsn := "host=" + dbConfig.Host +
-		" user=" + dbConfig.User +
-		" password=" + dbConfig.Password +
-		" dbname=" + dbConfig.Name +
-		" port=" + strconv.Itoa(dbConfig.Port) +
-		" sslmode=" + dbConfig.SSLMode +
-		" TimeZone=" + dbConfig.TimeZone
-
+	config := newGormConfig()
+	config.DisableAutomaticPing = true
 	var gormerr error
-	DB, gormerr = gorm.Open(postgres.Open(dsn), &gorm.Config{
-		Logger: logger.Default.LogMode(getGormLogLevel()),
-		NowFunc: func() time.Time {
-			return time.Now().UTC()
-		},
-	})
+	DB, gormerr = gorm.Open(dialector, config)
 
 	if gormerr != nil {
 		logs.Log.Fatal("Failed to connect to database",
-			zap.String("host", dbConfig.Host),
-			zap.Int("port", dbConfig.Port),
-			zap.String("user", dbConfig.User),
-			zap.String("database", dbConfig.Name),
+			zap.String("driver", driver),
 			zap.Error(gormerr),
 		)
 	}
 
+	if err := waitForDB(DB, driver); err != nil {
+		logs.Log.Fatal("Failed to connect to database",
+			zap.String("driver", driver),
+			zap.Error(err),
+		)
+	}
+
 	sqlDB, err := DB.DB()
 	if err != nil {
 		logs.Log.Fatal("Failed to get underlying sql.DB instance", zap.Error(err))
@@ -97,16 +100,113 @@ func InitDB() {
 	maxIdleConns := env.GetEnvAsInt("DB_MAX_IDLE_CONNS", 10)
 	maxOpenConns := env.GetEnvAsInt("DB_MAX_OPEN_CONNS", 100)
 	connMaxLifetimeMinutes := env.GetEnvAsInt("DB_CONN_MAX_LIFETIME_MINUTES", 60)
+	if driver == DriverSQLite && isSQLiteMemory(sqlitePath()) {
+		maxIdleConns, maxOpenConns, connMaxLifetimeMinutes = 1, 1, 0
+	}
 
 	sqlDB.SetMaxIdleConns(maxIdleConns)
 	sqlDB.SetMaxOpenConns(maxOpenConns)
 	sqlDB.SetConnMaxLifetime(time.Duration(connMaxLifetimeMinutes) * time.Minute)
 
 	logs.Log.Info("Database connection established successfully",
+		zap.String("driver", driver),
 		zap.Int("max_idle_conns", maxIdleConns),
 		zap.Int("max_open_conns", maxOpenConns),
 		zap.Int("conn_max_lifetime_minutes", connMaxLifetimeMinutes),
 	)
+
+	initReplicas(driver, maxIdleConns, maxOpenConns, time.Duration(connMaxLifetimeMinutes)*time.Minute)
+
+	dbhealth.Start(DB, time.Duration(env.GetEnvAsInt("DB_HEALTH_INTERVAL_SECONDS", 5))*time.Second)
+}
+
+func waitForDB(db *gorm.DB, driver string) error {
+	timeout := time.Duration(env.GetEnvAsInt("DB_CONNECT_TIMEOUT_SECONDS", 60)) * time.Second
+	delay := time.Duration(env.GetEnvAsInt("DB_CONNECT_RETRY_INITIAL_MS", 500)) * time.Millisecond
+	maxDelay := time.Duration(env.GetEnvAsInt("DB_CONNECT_RETRY_MAX_MS", 10000)) * time.Millisecond
+	deadline := time.Now().Add(timeout)
+
+	for attempt := 1; ; attempt++ {
+		remaining := time.Until(deadline)
+		err := dbhealth.Ping(db, min(connectAttemptTimeout, max(remaining, time.Millisecond)))
+		if err == nil {
+			return nil
+		}
+
+		remaining = time.Until(deadline)
+		if remaining <= 0 {
+			return fmt.Errorf("%d denemeden sonra veritabanına bağlanılamadı (%s): %w", attempt, timeout, err)
+		}
+
+		wait := min(delay, remaining)
+		logs.Log.Warn("Veritabanına bağlanılamadı, tekrar denenecek",
+			zap.String("driver", driver),
+			zap.Int("attempt", attempt),
+			zap.Duration("retry_in", wait),
+			zap.Error(err),
+		)
+		time.Sleep(wait)
+		delay = min(delay*2, maxDelay)
+	}
+}
+
+func newGormConfig() *gorm.Config {
+	return &gorm.Config{
+		Logger: logger.Default.LogMode(getGormLogLevel()),
+		NowFunc: func() time.Time {
+			return time.Now().UTC()
+		},
+	}
+}
+
+func postgresDialector() gorm.Dialector {
+	dbConfig, pgxConfig, err := LoadPostgresConfig()
+	if err != nil {
+		logs.Log.Fatal("Invalid database configuration",
+			zap.String("driver", DriverPostgres),
+			zap.String("source", dbConfig.Source),
+			zap.Error(err),
+		)
+	}
+
+	logs.Log.Info("Database configuration loaded", dbConfig.LogFields()...)
+
+	connectAttemptTimeout = dbConfig.ConnectTimeout
+	return postgres.New(postgres.Config{Conn: stdlib.OpenDB(*pgxConfig)})
+}
+
+func sqliteDialector() gorm.Dialector {
+	path := sqlitePath()
+	logs.Log.Info("Database configuration loaded",
+		zap.String("driver", DriverSQLite),
+		zap.String("database", path),
+	)
+
+	dsn := path
+	if isSQLiteMemory(path) {
+		dsn = "file::memory:"
+	}
+	separator := "?"
+	if strings.Contains(dsn, "?") {
+		separator = "&"
+	}
+	dsn += separator + "_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)"
+	if !isSQLiteMemory(path) {
+		dsn += "&_pragma=journal_mode(WAL)"
+	}
+	return sqlite.Open(dsn)
+}
+
+func sqlitePath() string {
+	return env.GetEnvWithDefault("DB_DATABASE", "zatrano.db")
+}
+
+func IsInMemoryDB() bool {
+	return env.GetEnvWithDefault("DB_DRIVER", DriverPostgres) == DriverSQLite && isSQLiteMemory(sqlitePath())
+}
+
+func isSQLiteMemory(path string) bool {
+	return path == ":memory:" || strings.HasPrefix(path, "file::memory:")
 }
 
 func getGormLogLevel() logger.LogLevel {
@@ -135,6 +235,8 @@ func CloseDB() error {
 		return nil
 	}
 
+	dbhealth.Stop()
+
 	sqlDB, err := DB.DB()
 	if err != nil {
 		logs.Log.Error("Failed to get database instance for closing", zap.Error(err))
@@ -147,6 +249,7 @@ func CloseDB() error {
 		return err
 	}
 
+	replica.Close()
 	logs.SLog.Info("Database connection closed successfully.")
 	DB = nil
 	return nil
@@ -0,0 +1,398 @@
+package configs
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"zatrano/pkg/env"
+	"zatrano/pkg/redact"
+
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+const (
+	sourceDatabaseURL        = "DATABASE_URL"
+	sourceEnv                = "DB_*"
+	sourceReplica            = "DB_REPLICA_DSNS"
+	maxApplicationNameLength = 63
+)
+
+var (
+	validSSLModes            = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+	validStatementCacheModes = []string{"cache_statement", "cache_describe", "describe_exec", "exec", "simple_protocol"}
+	searchPathEntryPattern   = regexp.MustCompile(`^(?:[A-Za-z_][A-Za-z0-9_$]*|"\$user")$`)
+)
+
+func LoadPostgresConfig() (DatabaseConfig, *pgx.ConnConfig, error) {
+	var (
+		config  DatabaseConfig
+		fromURL map[string]bool
+		errs    []error
+	)
+
+	if raw := strings.TrimSpace(os.Getenv("DATABASE_URL")); raw != "" {
+		var err error
+		config, fromURL, err = parsePostgresURL(sourceDatabaseURL, raw)
+		if err != nil {
+			return config, nil, err
+		}
+	} else {
+		config, errs = postgresConfigFromEnv()
+		fromURL = map[string]bool{}
+	}
+
+	return finalizePostgresConfig(config, fromURL, errs)
+}
+
+func LoadReplicaPostgresConfig(name, raw string) (DatabaseConfig, *pgx.ConnConfig, error) {
+	config, fromURL, err := parsePostgresURL(sourceReplica+" "+name, raw)
+	if err != nil {
+		return config, nil, err
+	}
+	return finalizePostgresConfig(config, fromURL, nil)
+}
+
+func finalizePostgresConfig(config DatabaseConfig, fromURL map[string]bool, errs []error) (DatabaseConfig, *pgx.ConnConfig, error) {
+	errs = append(errs, applyPostgresOptions(&config, fromURL)...)
+	errs = append(errs, config.validate()...)
+	if len(errs) > 0 {
+		return config, nil, errors.Join(errs...)
+	}
+
+	pgxConfig, err := pgx.ParseConfig(config.DSN())
+	if err != nil {
+		return config, nil, fmt.Errorf("bağlantı ayarları çözümlenemedi: %w", err)
+	}
+	pgxConfig.RuntimeParams["timezone"] = config.TimeZone
+	return config, pgxConfig, nil
+}
+
+func postgresConfigFromEnv() (DatabaseConfig, []error) {
+	config := DatabaseConfig{
+		Source:   sourceEnv,
+		Host:     env.GetEnvWithDefault("DB_HOST", "localhost"),
+		User:     env.GetEnvWithDefault("DB_USERNAME", "postgres"),
+		Password: env.GetEnvWithDefault("DB_PASSWORD", ""),
+		Name:     env.GetEnvWithDefault("DB_DATABASE", "myapp"),
+	}
+
+	portStr := env.GetEnvWithDefault("DB_PORT", "5432")
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return config, []error{fmt.Errorf("DB_PORT sayı olmalı: %q", portStr)}
+	}
+	config.Port = port
+	return config, nil
+}
+
+func parsePostgresURL(source, raw string) (DatabaseConfig, map[string]bool, error) {
+	config := DatabaseConfig{Source: source, Port: 5432, Extra: url.Values{}}
+	fromURL := map[string]bool{}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return config, nil, fmt.Errorf("%s çözümlenemedi: %w", source, err)
+	}
+	if u.Scheme != "postgres" && u.Scheme != "postgresql" {
+		return config, nil, fmt.Errorf("%s postgres:// veya postgresql:// ile başlamalı, %q desteklenmiyor", source, u.Scheme)
+	}
+
+	config.Host = u.Hostname()
+	config.Name = strings.TrimPrefix(u.Path, "/")
+	if u.User != nil {
+		config.User = u.User.Username()
+		config.Password, _ = u.User.Password()
+	}
+	if p := u.Port(); p != "" {
+		if config.Port, err = strconv.Atoi(p); err != nil {
+			return config, nil, fmt.Errorf("%s içindeki port sayı olmalı: %q", source, p)
+		}
+	}
+
+	var errs []error
+	for key, values := range u.Query() {
+		value := values[len(values)-1]
+		fromURL[key] = true
+		switch key {
+		case "host":
+			config.Host = value
+		case "port":
+			if config.Port, err = strconv.Atoi(value); err != nil {
+				errs = append(errs, fmt.Errorf("%s içindeki port sayı olmalı: %q", source, value))
+			}
+		case "user":
+			config.User = value
+		case "password":
+			config.Password = value
+		case "dbname":
+			config.Name = value
+		case "sslmode":
+			config.SSLMode = value
+		case "TimeZone", "timezone", "time_zone":
+			config.TimeZone = value
+			fromURL["TimeZone"] = true
+		case "application_name":
+			config.ApplicationName = value
+		case "statement_timeout":
+			if config.StatementTimeout, err = parseStatementTimeout(value); err != nil {
+				errs = append(errs, fmt.Errorf("%s statement_timeout: %w", source, err))
+			}
+		case "search_path":
+			config.SearchPath = splitList(value)
+		case "connect_timeout":
+			seconds, err := strconv.Atoi(value)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s connect_timeout saniye cinsinden sayı olmalı: %q", source, value))
+			}
+			config.ConnectTimeout = time.Duration(seconds) * time.Second
+		case "sslrootcert":
+			config.SSLRootCert = value
+		case "sslcert":
+			config.SSLCert = value
+		case "sslkey":
+			config.SSLKey = value
+		case "sslpassword":
+			config.SSLKeyPassword = value
+		case "default_query_exec_mode":
+			config.StatementCacheMode = value
+		case "statement_cache_capacity":
+			if config.StatementCacheSize, err = strconv.Atoi(value); err != nil {
+				errs = append(errs, fmt.Errorf("%s statement_cache_capacity sayı olmalı: %q", source, value))
+			}
+		default:
+			config.Extra[key] = values
+		}
+	}
+	return config, fromURL, errors.Join(errs...)
+}
+
+func applyPostgresOptions(config *DatabaseConfig, fromURL map[string]bool) []error {
+	var errs []error
+	str := func(key, name, fallback string, target *string) {
+		if !fromURL[key] {
+			*target = env.GetEnvWithDefault(name, fallback)
+		}
+	}
+
+	str("sslmode", "DB_SSL_MODE", "disable", &config.SSLMode)
+	str("TimeZone", "DB_TIMEZONE", "UTC", &config.TimeZone)
+	str("application_name", "DB_APPLICATION_NAME", "zatrano", &config.ApplicationName)
+	str("sslrootcert", "DB_SSL_ROOT_CERT", "", &config.SSLRootCert)
+	str("sslcert", "DB_SSL_CERT", "", &config.SSLCert)
+	str("sslkey", "DB_SSL_KEY", "", &config.SSLKey)
+	str("sslpassword", "DB_SSL_KEY_PASSWORD", "", &config.SSLKeyPassword)
+	str("default_query_exec_mode", "DB_STATEMENT_CACHE_MODE", "", &config.StatementCacheMode)
+
+	if !fromURL["search_path"] {
+		config.SearchPath = splitList(os.Getenv("DB_SEARCH_PATH"))
+	}
+	if !fromURL["statement_timeout"] {
+		if value := os.Getenv("DB_STATEMENT_TIMEOUT"); value != "" {
+			timeout, err := parseStatementTimeout(value)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("DB_STATEMENT_TIMEOUT: %w", err))
+			}
+			config.StatementTimeout = timeout
+		}
+	}
+	if !fromURL["connect_timeout"] {
+		seconds, err := strconv.Atoi(env.GetEnvWithDefault("DB_CONNECT_ATTEMPT_TIMEOUT_SECONDS", "5"))
+		if err != nil {
+			errs = append(errs, errors.New("DB_CONNECT_ATTEMPT_TIMEOUT_SECONDS saniye cinsinden sayı olmalı"))
+		}
+		config.ConnectTimeout = time.Duration(seconds) * time.Second
+	}
+	if !fromURL["statement_cache_capacity"] {
+		if value := os.Getenv("DB_STATEMENT_CACHE_CAPACITY"); value != "" {
+			size, err := strconv.Atoi(value)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("DB_STATEMENT_CACHE_CAPACITY sayı olmalı: %q", value))
+			}
+			config.StatementCacheSize = size
+		}
+	}
+	return errs
+}
+
+func (c DatabaseConfig) validate() []error {
+	var errs []error
+	fail := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	if c.Host == "" {
+		fail("veritabanı sunucusu (host) boş olamaz")
+	}
+	if c.Port < 1 || c.Port > 65535 {
+		fail("port 1-65535 aralığında olmalı: %d", c.Port)
+	}
+	if c.User == "" {
+		fail("veritabanı kullanıcı adı boş olamaz")
+	}
+	if c.Name == "" {
+		fail("veritabanı adı boş olamaz")
+	}
+	if !slices.Contains(validSSLModes, c.SSLMode) {
+		fail("geçersiz sslmode %q (%s)", c.SSLMode, strings.Join(validSSLModes, ", "))
+	}
+	if _, err := time.LoadLocation(c.TimeZone); err != nil {
+		fail("geçersiz zaman dilimi %q", c.TimeZone)
+	}
+
+	if len(c.ApplicationName) > maxApplicationNameLength {
+		fail("application_name en fazla %d karakter olabilir", maxApplicationNameLength)
+	}
+	for _, r := range c.ApplicationName {
+		if r < 0x20 || r > 0x7e {
+			fail("application_name yalnızca yazdırılabilir ASCII karakterler içerebilir")
+			break
+		}
+	}
+	if c.StatementTimeout < 0 {
+		fail("statement_timeout negatif olamaz")
+	}
+	for _, entry := range c.SearchPath {
+		if !searchPathEntryPattern.MatchString(entry) {
+			fail("search_path içinde geçersiz şema adı %q", entry)
+		}
+	}
+	if c.ConnectTimeout < time.Second {
+		fail("connect_timeout en az 1 saniye olmalı")
+	}
+
+	if c.StatementCacheMode != "" && !slices.Contains(validStatementCacheModes, c.StatementCacheMode) {
+		fail("geçersiz sorgu önbellek modu %q (%s)", c.StatementCacheMode, strings.Join(validStatementCacheModes, ", "))
+	}
+	if c.StatementCacheSize < 0 {
+		fail("statement_cache_capacity negatif olamaz")
+	}
+
+	if (c.SSLCert == "") != (c.SSLKey == "") {
+		fail("istemci sertifikası için sslcert ve sslkey birlikte tanımlanmalı")
+	}
+	if c.SSLKeyPassword != "" && c.SSLKey == "" {
+		fail("sslpassword yalnızca sslkey ile birlikte kullanılabilir")
+	}
+	if c.SSLMode == "disable" && (c.SSLRootCert != "" || c.SSLCert != "") {
+		fail("SSL sertifikaları tanımlı ancak sslmode=disable")
+	}
+	for name, path := range map[string]string{"sslrootcert": c.SSLRootCert, "sslcert": c.SSLCert, "sslkey": c.SSLKey} {
+		if path == "" {
+			continue
+		}
+		if info, err := os.Stat(path); err != nil {
+			fail("%s dosyası okunamadı: %s", name, path)
+		} else if info.IsDir() {
+			fail("%s bir dosya olmalı: %s", name, path)
+		}
+	}
+	return errs
+}
+
+func (c DatabaseConfig) DSN() string {
+	query := url.Values{}
+	for key, values := range c.Extra {
+		query[key] = values
+	}
+
+	set := func(key, value string) {
+		if value != "" {
+			query.Set(key, value)
+		}
+	}
+	set("sslmode", c.SSLMode)
+	set("application_name", c.ApplicationName)
+	set("search_path", strings.Join(c.SearchPath, ","))
+	set("sslrootcert", c.SSLRootCert)
+	set("sslcert", c.SSLCert)
+	set("sslkey", c.SSLKey)
+	set("sslpassword", c.SSLKeyPassword)
+	set("default_query_exec_mode", c.StatementCacheMode)
+	if c.StatementTimeout > 0 {
+		query.Set("statement_timeout", strconv.FormatInt(c.StatementTimeout.Milliseconds(), 10))
+	}
+	if c.ConnectTimeout > 0 {
+		query.Set("connect_timeout", strconv.Itoa(int(c.ConnectTimeout/time.Second)))
+	}
+	if c.StatementCacheSize > 0 {
+		query.Set("statement_cache_capacity", strconv.Itoa(c.StatementCacheSize))
+	}
+
+	u := url.URL{Scheme: "postgres", User: url.User(c.User), Path: "/" + c.Name}
+	if c.Password != "" {
+		u.User = url.UserPassword(c.User, c.Password)
+	}
+	if strings.HasPrefix(c.Host, "/") {
+		query.Set("host", c.Host)
+		query.Set("port", strconv.Itoa(c.Port))
+	} else {
+		u.Host = net.JoinHostPort(c.Host, strconv.Itoa(c.Port))
+	}
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+func (c DatabaseConfig) LogFields() []zap.Field {
+	extra := make([]string, 0, len(c.Extra))
+	for key := range c.Extra {
+		extra = append(extra, key)
+	}
+	slices.Sort(extra)
+
+	return []zap.Field{
+		zap.String("driver", DriverPostgres),
+		zap.String("source", c.Source),
+		zap.String("host", c.Host),
+		zap.Int("port", c.Port),
+		zap.String("user", c.User),
+		zap.String("password", redact.String(c.Password)),
+		zap.String("database", c.Name),
+		zap.String("sslmode", c.SSLMode),
+		zap.String("timezone", c.TimeZone),
+		zap.String("application_name", c.ApplicationName),
+		zap.Duration("statement_timeout", c.StatementTimeout),
+		zap.Strings("search_path", c.SearchPath),
+		zap.Duration("connect_timeout", c.ConnectTimeout),
+		zap.String("sslrootcert", c.SSLRootCert),
+		zap.String("sslcert", c.SSLCert),
+		zap.String("sslkey", c.SSLKey),
+		zap.String("sslpassword", redact.String(c.SSLKeyPassword)),
+		zap.String("statement_cache_mode", c.StatementCacheMode),
+		zap.Int("statement_cache_capacity", c.StatementCacheSize),
+		zap.Strings("extra_params", extra),
+	}
+}
+
+func parseStatementTimeout(value string) (time.Duration, error) {
+	if ms, err := strconv.Atoi(value); err == nil {
+		return time.Duration(ms) * time.Millisecond, nil
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("milisaniye veya süre (örn. 30s) olmalı: %q", value)
+	}
+	return timeout, nil
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
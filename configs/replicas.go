@@ -0,0 +1,65 @@
+package configs
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"zatrano/pkg/env"
+	"zatrano/pkg/logs"
+	"zatrano/pkg/replica"
+
+	"github.com/glebarez/sqlite"
+	"github.com/jackc/pgx/v5/stdlib"
+	"go.uber.org/zap"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func initReplicas(driver string, maxIdleConns, maxOpenConns int, connMaxLifetime time.Duration) {
+	raw := strings.TrimSpace(env.GetEnvWithDefault("DB_REPLICA_DSNS", ""))
+	if raw == "" {
+		return
+	}
+
+	for i, dsn := range strings.Fields(raw) {
+		name := fmt.Sprintf("replica-%d", i+1)
+
+		var dialector gorm.Dialector
+		if driver == DriverSQLite {
+			dialector = sqlite.Open(dsn)
+		} else {
+			replicaConfig, pgxConfig, err := LoadReplicaPostgresConfig(name, dsn)
+			if err != nil {
+				logs.Log.Error("Okuma replikası ayarları geçersiz, atlanıyor",
+					zap.String("replica", name),
+					zap.String("source", replicaConfig.Source),
+					zap.Error(err),
+				)
+				continue
+			}
+			logs.Log.Info("Okuma replikası ayarları yüklendi", replicaConfig.LogFields()...)
+			dialector = postgres.New(postgres.Config{Conn: stdlib.OpenDB(*pgxConfig)})
+		}
+
+		config := newGormConfig()
+		config.DisableAutomaticPing = true
+		db, err := gorm.Open(dialector, config)
+		if err != nil {
+			logs.Log.Error("Okuma replikası yapılandırılamadı, atlanıyor", zap.String("replica", name), zap.Error(err))
+			continue
+		}
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.SetMaxIdleConns(maxIdleConns)
+			sqlDB.SetMaxOpenConns(maxOpenConns)
+			sqlDB.SetConnMaxLifetime(connMaxLifetime)
+		}
+
+		pool := replica.Register(name, db)
+		logs.Log.Info("Okuma replikası eklendi", zap.String("replica", name), zap.Bool("healthy", pool.Healthy()))
+	}
+
+	replica.SetStickiness(time.Duration(env.GetEnvAsInt("DB_REPLICA_STICKY_SECONDS", 5)) * time.Second)
+	interval := time.Duration(env.GetEnvAsInt("DB_REPLICA_HEALTH_INTERVAL_SECONDS", 10)) * time.Second
+	replica.StartHealthCheck(interval)
+}
@@ -0,0 +1,204 @@
+package archive
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"zatrano/database/factories"
+
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm/schema"
+)
+
+var ErrUnknownAnonymizer = errors.New("anonimleştirici kayıtlarda bulunamadı")
+
+type Value struct {
+	Table  string
+	Column string
+	ID     any
+	Value  any
+	Row    map[string]any
+	Faker  *factories.Faker
+}
+
+type Anonymizer func(v Value) any
+
+type Rule struct {
+	Table      string
+	Column     string
+	Anonymizer string
+}
+
+func (r Rule) String() string {
+	return r.Table + "." + r.Column + "=" + r.Anonymizer
+}
+
+var (
+	anonymizersMu sync.RWMutex
+	anonymizers   = map[string]Anonymizer{
+		"name": func(v Value) any {
+			return v.Faker.FirstName() + " " + v.Faker.LastName()
+		},
+		"account": func(v Value) any {
+			return fmt.Sprintf("anonim%v@ornek.invalid", v.ID)
+		},
+		"email": func(v Value) any {
+			return fmt.Sprintf("eposta%v@ornek.invalid", v.ID)
+		},
+		"phone": func(v Value) any {
+			return v.Faker.Phone()
+		},
+		"ip": func(v Value) any {
+			return "0.0.0.0"
+		},
+		"empty": func(v Value) any {
+			return ""
+		},
+		"null": func(v Value) any {
+			return nil
+		},
+		"hash": func(v Value) any {
+			sum := sha256.Sum256([]byte(fmt.Sprint(v.Value)))
+			return hex.EncodeToString(sum[:8])
+		},
+		"password": func(v Value) any {
+			return anonymousPasswordHash()
+		},
+	}
+)
+
+var anonymousPasswordHash = sync.OnceValue(func() string {
+	hash, err := bcrypt.GenerateFromPassword([]byte(factories.FakeUserPassword), bcrypt.DefaultCost)
+	if err != nil {
+		panic(err)
+	}
+	return string(hash)
+})
+
+func RegisterAnonymizer(name string, fn Anonymizer) {
+	anonymizersMu.Lock()
+	defer anonymizersMu.Unlock()
+	anonymizers[name] = fn
+}
+
+func AnonymizerNames() []string {
+	anonymizersMu.RLock()
+	defer anonymizersMu.RUnlock()
+	return namesLocked()
+}
+
+func DefaultRules() []Rule {
+	return []Rule{
+		{Table: "users", Column: "name", Anonymizer: "name"},
+		{Table: "users", Column: "account", Anonymizer: "account"},
+		{Table: "users", Column: "email", Anonymizer: "email"},
+		{Table: "users", Column: "phone", Anonymizer: "phone"},
+		{Table: "users", Column: "password", Anonymizer: "password"},
+		{Table: "login_events", Column: "ip", Anonymizer: "ip"},
+		{Table: "login_events", Column: "user_agent", Anonymizer: "empty"},
+		{Table: "audit_logs", Column: "ip", Anonymizer: "ip"},
+		{Table: "audit_logs", Column: "changes", Anonymizer: "null"},
+	}
+}
+
+func ParseRules(spec string) ([]Rule, error) {
+	var rules []Rule
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		if part == "default" {
+			rules = append(rules, DefaultRules()...)
+			continue
+		}
+		target, name, ok := strings.Cut(part, "=")
+		table, column, okColumn := strings.Cut(strings.TrimSpace(target), ".")
+		if !ok || !okColumn {
+			return nil, fmt.Errorf("geçersiz anonimleştirme kuralı %q (beklenen: tablo.kolon=anonimleştirici veya default)", part)
+		}
+		rules = append(rules, Rule{Table: table, Column: column, Anonymizer: strings.TrimSpace(name)})
+	}
+	return rules, nil
+}
+
+type anonymizer struct {
+	rules map[string]map[string]Anonymizer
+}
+
+func newAnonymizer(rules []Rule, schemas map[string]*schema.Schema) (*anonymizer, error) {
+	anonymizersMu.RLock()
+	defer anonymizersMu.RUnlock()
+
+	a := &anonymizer{rules: make(map[string]map[string]Anonymizer)}
+	for _, rule := range rules {
+		if !slices.Contains(TableNames(), rule.Table) {
+			return nil, fmt.Errorf("anonimleştirme kuralı %s: %w: %s", rule, ErrUnknownTable, rule.Table)
+		}
+		sch, ok := schemas[rule.Table]
+		if !ok {
+			continue
+		}
+		if sch.LookUpField(rule.Column) == nil {
+			return nil, fmt.Errorf("anonimleştirme kuralı %s: %s tablosunda %s kolonu yok", rule, rule.Table, rule.Column)
+		}
+		fn, ok := anonymizers[rule.Anonymizer]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s (mevcut: %s)", ErrUnknownAnonymizer, rule.Anonymizer, strings.Join(namesLocked(), ", "))
+		}
+		if a.rules[rule.Table] == nil {
+			a.rules[rule.Table] = make(map[string]Anonymizer)
+		}
+		a.rules[rule.Table][rule.Column] = fn
+	}
+	return a, nil
+}
+
+func namesLocked() []string {
+	names := make([]string, 0, len(anonymizers))
+	for name := range anonymizers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (a *anonymizer) apply(table string, row map[string]any, id any) {
+	for column, fn := range a.rules[table] {
+		value, ok := row[column]
+		if !ok || isEmpty(value) {
+			continue
+		}
+		row[column] = fn(Value{
+			Table:  table,
+			Column: column,
+			ID:     id,
+			Value:  value,
+			Row:    row,
+			Faker:  factories.NewFaker(fakerSeed(table, column, id)),
+		})
+	}
+}
+
+func isEmpty(value any) bool {
+	if value == nil {
+		return true
+	}
+	if s, ok := value.(string); ok {
+		return s == ""
+	}
+	return false
+}
+
+func fakerSeed(table, column string, id any) int64 {
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%s.%s:%v", table, column, id)
+	return int64(h.Sum64() >> 1)
+}
@@ -0,0 +1,220 @@
+package archive
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"zatrano/models"
+	"zatrano/pkg/logs"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+const (
+	Format           = "zatrano-archive"
+	Version          = 1
+	DefaultBatchSize = 1000
+)
+
+var (
+	ErrUnknownTable = errors.New("tablo arşiv kayıtlarında bulunamadı")
+	ErrInvalid      = errors.New("geçersiz arşiv dosyası")
+)
+
+type Table struct {
+	Name      string
+	Model     any
+	DependsOn []string
+	Deferred  []string
+}
+
+func Tables() []Table {
+	return []Table{
+		{Name: "organizations", Model: &models.Organization{}},
+		{Name: "users", Model: &models.User{}, DependsOn: []string{"organizations"}, Deferred: []string{"manager_id"}},
+		{Name: "settings", Model: &models.Setting{}, DependsOn: []string{"users"}},
+		{Name: "login_events", Model: &models.LoginEvent{}, DependsOn: []string{"users"}},
+		{Name: "notifications", Model: &models.Notification{}, DependsOn: []string{"users"}},
+		{Name: "audit_logs", Model: &models.AuditLog{}, DependsOn: []string{"organizations", "users"}},
+	}
+}
+
+func TableNames() []string {
+	var names []string
+	for _, table := range Tables() {
+		names = append(names, table.Name)
+	}
+	return names
+}
+
+type Options struct {
+	Include   []string
+	Exclude   []string
+	Rules     []Rule
+	BatchSize int
+	Truncate  bool
+	Schema    string
+}
+
+type Header struct {
+	Format     string      `json:"format"`
+	Version    int         `json:"version"`
+	CreatedAt  time.Time   `json:"created_at"`
+	Driver     string      `json:"driver"`
+	Schema     string      `json:"schema,omitempty"`
+	Tables     []TableInfo `json:"tables"`
+	Anonymized []string    `json:"anonymized,omitempty"`
+}
+
+type TableInfo struct {
+	Name    string   `json:"name"`
+	Columns []string `json:"columns"`
+}
+
+type TableResult struct {
+	Name    string
+	Rows    int
+	Skipped int
+}
+
+type entry struct {
+	Type   string          `json:"type"`
+	Header *Header         `json:"header,omitempty"`
+	Table  string          `json:"table,omitempty"`
+	Data   json.RawMessage `json:"data,omitempty"`
+	Counts map[string]int  `json:"counts,omitempty"`
+}
+
+func Create(path string) (io.WriteCloser, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasSuffix(path, ".gz") {
+		return file, nil
+	}
+	return &gzipWriter{Writer: gzip.NewWriter(file), file: file}, nil
+}
+
+func Open(path string) (io.ReadCloser, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	reader := bufio.NewReader(file)
+	if magic, err := reader.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(reader)
+		if err != nil {
+			file.Close()
+			return nil, fmt.Errorf("%w: gzip açılamadı: %v", ErrInvalid, err)
+		}
+		return &gzipReader{Reader: gz, file: file}, nil
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{reader, file}, nil
+}
+
+type gzipWriter struct {
+	*gzip.Writer
+	file *os.File
+}
+
+func (g *gzipWriter) Close() error {
+	return errors.Join(g.Writer.Close(), g.file.Close())
+}
+
+type gzipReader struct {
+	*gzip.Reader
+	file *os.File
+}
+
+func (g *gzipReader) Close() error {
+	return errors.Join(g.Reader.Close(), g.file.Close())
+}
+
+func selectTables(include, exclude []string) ([]Table, error) {
+	byName := make(map[string]Table)
+	for _, table := range Tables() {
+		byName[table.Name] = table
+	}
+	for _, name := range slices.Concat(include, exclude) {
+		if _, ok := byName[name]; !ok {
+			return nil, fmt.Errorf("%w: %s (mevcut: %s)", ErrUnknownTable, name, strings.Join(TableNames(), ", "))
+		}
+	}
+
+	selected := make(map[string]bool)
+	for _, table := range Tables() {
+		if (len(include) == 0 || slices.Contains(include, table.Name)) && !slices.Contains(exclude, table.Name) {
+			selected[table.Name] = true
+		}
+	}
+
+	var (
+		ordered []Table
+		visited = make(map[string]bool)
+		visit   func(name string, path []string) error
+	)
+	visit = func(name string, path []string) error {
+		if slices.Contains(path, name) {
+			return fmt.Errorf("tablolar arasında döngüsel bağımlılık: %s", strings.Join(append(path, name), " -> "))
+		}
+		if visited[name] {
+			return nil
+		}
+		table := byName[name]
+		for _, dependency := range table.DependsOn {
+			if err := visit(dependency, append(path, name)); err != nil {
+				return err
+			}
+		}
+		visited[name] = true
+		if selected[name] {
+			ordered = append(ordered, table)
+		}
+		return nil
+	}
+	for _, table := range Tables() {
+		if err := visit(table.Name, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, table := range ordered {
+		for _, dependency := range table.DependsOn {
+			if !selected[dependency] {
+				logs.SLog.Warnf("%s tablosu %s tablosuna bağlı ancak seçime dahil değil; hedefte ilgili kayıtların bulunması gerekir.", table.Name, dependency)
+			}
+		}
+	}
+	return ordered, nil
+}
+
+func parseSchema(db *gorm.DB, table Table) (*schema.Schema, error) {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(table.Model); err != nil {
+		return nil, fmt.Errorf("%s modeli çözümlenemedi: %w", table.Name, err)
+	}
+	return stmt.Schema, nil
+}
+
+func columns(sch *schema.Schema) []string {
+	var names []string
+	for _, field := range sch.Fields {
+		if field.DBName != "" {
+			names = append(names, field.DBName)
+		}
+	}
+	return names
+}
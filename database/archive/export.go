@@ -0,0 +1,109 @@
+package archive
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"time"
+
+	"zatrano/pkg/logs"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+func Export(db *gorm.DB, w io.Writer, opts Options) ([]TableResult, error) {
+	tables, err := selectTables(opts.Include, opts.Exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	schemas := make(map[string]*schema.Schema)
+	header := &Header{
+		Format:    Format,
+		Version:   Version,
+		CreatedAt: time.Now().UTC(),
+		Driver:    db.Dialector.Name(),
+		Schema:    opts.Schema,
+	}
+	for _, table := range tables {
+		sch, err := parseSchema(db, table)
+		if err != nil {
+			return nil, err
+		}
+		schemas[table.Name] = sch
+		header.Tables = append(header.Tables, TableInfo{Name: table.Name, Columns: columns(sch)})
+	}
+
+	anonymizer, err := newAnonymizer(opts.Rules, schemas)
+	if err != nil {
+		return nil, err
+	}
+	for _, rule := range opts.Rules {
+		if _, ok := schemas[rule.Table]; ok {
+			header.Anonymized = append(header.Anonymized, rule.String())
+		}
+	}
+
+	buffered := bufio.NewWriter(w)
+	encoder := json.NewEncoder(buffered)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(entry{Type: "header", Header: header}); err != nil {
+		return nil, err
+	}
+
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+
+	counts := make(map[string]int)
+	var results []TableResult
+	for _, table := range tables {
+		sch := schemas[table.Name]
+		count, err := exportTable(db, encoder, table, sch, anonymizer, batchSize)
+		if err != nil {
+			return nil, fmt.Errorf("%s tablosu dışa aktarılamadı: %w", table.Name, err)
+		}
+		counts[table.Name] = count
+		results = append(results, TableResult{Name: table.Name, Rows: count})
+		logs.SLog.Infof("%s: %d kayıt dışa aktarıldı.", table.Name, count)
+	}
+
+	if err := encoder.Encode(entry{Type: "footer", Counts: counts}); err != nil {
+		return nil, err
+	}
+	return results, buffered.Flush()
+}
+
+func exportTable(db *gorm.DB, encoder *json.Encoder, table Table, sch *schema.Schema, anonymizer *anonymizer, batchSize int) (int, error) {
+	count := 0
+	batch := reflect.New(reflect.SliceOf(sch.ModelType))
+	result := db.Unscoped().Model(table.Model).FindInBatches(batch.Interface(), batchSize, func(tx *gorm.DB, _ int) error {
+		items := batch.Elem()
+		for i := 0; i < items.Len(); i++ {
+			item := items.Index(i)
+			row := make(map[string]any, len(sch.Fields))
+			for _, field := range sch.Fields {
+				if field.DBName == "" {
+					continue
+				}
+				row[field.DBName], _ = field.ValueOf(tx.Statement.Context, item)
+			}
+			anonymizer.apply(table.Name, row, row[sch.PrioritizedPrimaryField.DBName])
+
+			data, err := json.Marshal(row)
+			if err != nil {
+				return err
+			}
+			if err := encoder.Encode(entry{Type: "row", Table: table.Name, Data: data}); err != nil {
+				return err
+			}
+			count++
+		}
+		return nil
+	})
+	return count, result.Error
+}
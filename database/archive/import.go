@@ -0,0 +1,281 @@
+package archive
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+
+	"zatrano/pkg/logs"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+type importer struct {
+	tx         *gorm.DB
+	tables     map[string]Table
+	schemas    map[string]*schema.Schema
+	anonymizer *anonymizer
+	batchSize  int
+
+	current  string
+	batch    []map[string]any
+	deferred map[any]map[string]any
+	results  map[string]*TableResult
+	order    []string
+	unknown  map[string]bool
+}
+
+func Import(db *gorm.DB, r io.Reader, opts Options) ([]TableResult, error) {
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+
+	var first entry
+	if err := decoder.Decode(&first); err != nil || first.Type != "header" || first.Header == nil {
+		return nil, fmt.Errorf("%w: başlık satırı okunamadı", ErrInvalid)
+	}
+	header := first.Header
+	if header.Format != Format {
+		return nil, fmt.Errorf("%w: beklenmeyen biçim %q", ErrInvalid, header.Format)
+	}
+	if header.Version > Version {
+		return nil, fmt.Errorf("%w: arşiv sürümü %d bu uygulamanın desteklediği sürümden (%d) yeni", ErrInvalid, header.Version, Version)
+	}
+	if header.Schema != "" && opts.Schema != "" && header.Schema != opts.Schema {
+		logs.SLog.Warnf("Arşiv %s şema sürümüyle alınmış, hedef veritabanı %s sürümünde; eksik veya fazla kolonlar atlanacak.", header.Schema, opts.Schema)
+	}
+
+	selected, err := selectTables(opts.Include, opts.Exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	imp := &importer{
+		tables:   make(map[string]Table),
+		schemas:  make(map[string]*schema.Schema),
+		results:  make(map[string]*TableResult),
+		unknown:  make(map[string]bool),
+		deferred: make(map[any]map[string]any),
+	}
+	for _, info := range header.Tables {
+		index := slices.IndexFunc(selected, func(t Table) bool { return t.Name == info.Name })
+		if index < 0 {
+			logs.SLog.Infof("%s tablosu seçimde olmadığından atlanıyor.", info.Name)
+			continue
+		}
+		table := selected[index]
+		sch, err := parseSchema(db, table)
+		if err != nil {
+			return nil, err
+		}
+		imp.tables[table.Name] = table
+		imp.schemas[table.Name] = sch
+		imp.order = append(imp.order, table.Name)
+		imp.results[table.Name] = &TableResult{Name: table.Name}
+	}
+	if imp.anonymizer, err = newAnonymizer(opts.Rules, imp.schemas); err != nil {
+		return nil, err
+	}
+	imp.batchSize = opts.BatchSize
+	if imp.batchSize <= 0 {
+		imp.batchSize = DefaultBatchSize
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		imp.tx = tx
+		if opts.Truncate {
+			if err := imp.truncate(); err != nil {
+				return err
+			}
+		}
+
+		read := make(map[string]int)
+		for {
+			var e entry
+			if err := decoder.Decode(&e); err != nil {
+				if errors.Is(err, io.EOF) {
+					return fmt.Errorf("%w: arşiv eksik, bitiş satırı bulunamadı", ErrInvalid)
+				}
+				return fmt.Errorf("%w: %v", ErrInvalid, err)
+			}
+
+			switch e.Type {
+			case "row":
+				read[e.Table]++
+				if err := imp.add(e.Table, e.Data); err != nil {
+					return err
+				}
+			case "footer":
+				if err := imp.finishTable(); err != nil {
+					return err
+				}
+				for name, count := range e.Counts {
+					if read[name] != count {
+						return fmt.Errorf("%w: %s tablosu için %d kayıt bekleniyordu, %d okundu", ErrInvalid, name, count, read[name])
+					}
+				}
+				return imp.resetSequences()
+			default:
+				return fmt.Errorf("%w: bilinmeyen satır tipi %q", ErrInvalid, e.Type)
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var results []TableResult
+	for _, name := range imp.order {
+		results = append(results, *imp.results[name])
+	}
+	return results, nil
+}
+
+func (imp *importer) truncate() error {
+	for i := len(imp.order) - 1; i >= 0; i-- {
+		name := imp.order[i]
+		if err := imp.tx.Exec("DELETE FROM ?", clause.Table{Name: name}).Error; err != nil {
+			return fmt.Errorf("%s tablosu temizlenemedi: %w", name, err)
+		}
+		logs.SLog.Infof("%s tablosu temizlendi.", name)
+	}
+	return nil
+}
+
+func (imp *importer) add(tableName string, data json.RawMessage) error {
+	sch, ok := imp.schemas[tableName]
+	if !ok {
+		return nil
+	}
+	if imp.current != tableName {
+		if err := imp.finishTable(); err != nil {
+			return err
+		}
+		imp.current = tableName
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("%w: %s satırı çözümlenemedi: %v", ErrInvalid, tableName, err)
+	}
+
+	row := make(map[string]any, len(raw))
+	for column, value := range raw {
+		field := sch.LookUpField(column)
+		if field == nil || field.DBName == "" {
+			if key := tableName + "." + column; !imp.unknown[key] {
+				imp.unknown[key] = true
+				logs.SLog.Warnf("%s kolonu hedef şemada bulunamadı, atlanıyor.", key)
+			}
+			continue
+		}
+		target := reflect.New(field.FieldType)
+		if err := json.Unmarshal(value, target.Interface()); err != nil {
+			return fmt.Errorf("%w: %s.%s değeri çözümlenemedi: %v", ErrInvalid, tableName, column, err)
+		}
+		row[field.DBName] = target.Elem().Interface()
+	}
+
+	id := row[sch.PrioritizedPrimaryField.DBName]
+	imp.anonymizer.apply(tableName, row, id)
+
+	for _, column := range imp.tables[tableName].Deferred {
+		if value, ok := row[column]; ok && value != nil && !reflect.ValueOf(value).IsZero() {
+			if imp.deferred[id] == nil {
+				imp.deferred[id] = make(map[string]any)
+			}
+			imp.deferred[id][column] = value
+			row[column] = nil
+		}
+	}
+
+	imp.batch = append(imp.batch, row)
+	if len(imp.batch) >= imp.batchSize {
+		return imp.flush()
+	}
+	return nil
+}
+
+func (imp *importer) flush() error {
+	if len(imp.batch) == 0 {
+		return nil
+	}
+	name := imp.current
+	pk := imp.schemas[name].PrioritizedPrimaryField.DBName
+	result := imp.results[name]
+
+	ids := make([]any, 0, len(imp.batch))
+	for _, row := range imp.batch {
+		ids = append(ids, row[pk])
+	}
+	var existing []any
+	if err := imp.tx.Table(name).Where(clause.IN{Column: clause.Column{Name: pk}, Values: ids}).Pluck(pk, &existing).Error; err != nil {
+		return fmt.Errorf("%s tablosundaki mevcut kayıtlar okunamadı: %w", name, err)
+	}
+	existingIDs := make(map[string]bool, len(existing))
+	for _, id := range existing {
+		existingIDs[fmt.Sprint(id)] = true
+	}
+
+	rows := imp.batch[:0]
+	for _, row := range imp.batch {
+		id := row[pk]
+		if existingIDs[fmt.Sprint(id)] {
+			delete(imp.deferred, id)
+			result.Skipped++
+			continue
+		}
+		rows = append(rows, row)
+	}
+	imp.batch = nil
+	if len(rows) == 0 {
+		return nil
+	}
+
+	created := imp.tx.Table(name).Clauses(clause.OnConflict{DoNothing: true}).Create(&rows)
+	if created.Error != nil {
+		return fmt.Errorf("%s kayıtları eklenemedi: %w", name, created.Error)
+	}
+	result.Rows += int(created.RowsAffected)
+	result.Skipped += len(rows) - int(created.RowsAffected)
+	return nil
+}
+
+func (imp *importer) finishTable() error {
+	if imp.current == "" {
+		return nil
+	}
+	if err := imp.flush(); err != nil {
+		return err
+	}
+
+	name := imp.current
+	pk := imp.schemas[name].PrioritizedPrimaryField.DBName
+	for id, values := range imp.deferred {
+		if err := imp.tx.Table(name).Where(clause.Eq{Column: clause.Column{Name: pk}, Value: id}).Updates(values).Error; err != nil {
+			return fmt.Errorf("%s tablosundaki ertelenmiş kolonlar güncellenemedi: %w", name, err)
+		}
+	}
+	imp.deferred = make(map[any]map[string]any)
+	logs.SLog.Infof("%s: %d kayıt eklendi, %d kayıt zaten mevcut olduğu için atlandı.", name, imp.results[name].Rows, imp.results[name].Skipped)
+	return nil
+}
+
+func (imp *importer) resetSequences() error {
+	if imp.tx.Dialector.Name() != "postgres" {
+		return nil
+	}
+	for _, name := range imp.order {
+		pk := imp.schemas[name].PrioritizedPrimaryField.DBName
+		err := imp.tx.Exec("SELECT setval(pg_get_serial_sequence(?, ?), COALESCE(MAX(?), 0) + 1, false) FROM ?",
+			name, pk, clause.Column{Name: pk}, clause.Table{Name: name}).Error
+		if err != nil {
+			return fmt.Errorf("%s tablosunun sıra sayacı güncellenemedi: %w", name, err)
+		}
+	}
+	return nil
+}
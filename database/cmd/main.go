@@ -2,26 +2,324 @@ package main
 
 import (
 	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
 
 	"zatrano/configs"
 	"zatrano/database"
+	"zatrano/database/archive"
+	"zatrano/database/factories"
+	"zatrano/database/migrations"
+	"zatrano/pkg/env"
 	"zatrano/pkg/logs"
+
+	"gorm.io/gorm"
 )
 
 func main() {
 	logs.InitLogger()
 	defer logs.SyncLogger()
-	migrateFlag := flag.Bool("migrate", false, "Veritabanı başlatma işlemini çalıştır (migrasyonları içerir)")
-	seedFlag := flag.Bool("seed", false, "Veritabanı başlatma işlemini çalıştır (seederları içerir)")
+	migrateFlag := flag.Bool("migrate", false, "Veritabanı başlatma işlemini çalıştır (bekleyen migrasyonları içerir)")
+	statusFlag := flag.Bool("status", false, "Migrasyonların uygulanma durumunu listele")
+	upFlag := flag.Bool("up", false, "Bekleyen tüm migrasyonları uygula")
+	downFlag := flag.Int("down", 0, "Son uygulanan N migrasyonu geri al")
+	toFlag := flag.String("to", "", "Veritabanını belirtilen migrasyon kimliğine taşı (ileri veya geri)")
+	newFlag := flag.String("new", "", "Belirtilen adla zaman damgalı boş bir SQL migrasyon dosya çifti oluştur")
+	seedFlag := &seedList{}
+	flag.Var(seedFlag, "seed", "Seeder'ları çalıştır; yalnızca belirli seeder'lar için -seed=users,settings")
+	seedersFlag := flag.Bool("seeders", false, "Kayıtlı seeder'ları ortam ve çalıştırılma durumlarıyla listele")
+	fakeFlag := flag.String("fake", "", "Geliştirme veritabanına sahte kayıtlar ekle (örnek: -fake users=5000)")
+	fakeSeedFlag := flag.Int64("fake-seed", 0, "Sahte veriler için rastgelelik tohumu (aynı tohum aynı verileri üretir, 0 ise rastgele)")
+	fakeBatchFlag := flag.Int("fake-batch", factories.DefaultBatchSize, "Sahte kayıtların tek sorguda eklenecek adedi")
+	exportFlag := flag.String("export", "", "Seçili tabloları JSON-lines arşiv dosyasına aktar (.gz uzantısı ile sıkıştırılır)")
+	importFlag := flag.String("import", "", "JSON-lines arşiv dosyasındaki kayıtları veritabanına aktar")
+	tablesFlag := flag.String("tables", "", "Arşive dahil edilecek tablolar, virgülle ayrılmış (boşsa tümü: "+strings.Join(archive.TableNames(), ",")+")")
+	excludeTablesFlag := flag.String("exclude-tables", "", "Arşivden hariç tutulacak tablolar, virgülle ayrılmış")
+	anonymizeFlag := flag.String("anonymize", "", "Anonimleştirme kuralları: default veya tablo.kolon=anonimleştirici (mevcut: "+strings.Join(archive.AnonymizerNames(), ",")+")")
+	importTruncateFlag := flag.Bool("import-truncate", false, "İçe aktarmadan önce seçili tablolardaki tüm kayıtları sil")
+	protectFlag := flag.String("protect", "", "Belirtilen hesabı korumalı olarak işaretle")
+	unprotectFlag := flag.String("unprotect", "", "Belirtilen hesabın korumasını kaldır")
+	grantSuperAdminFlag := flag.String("grant-super-admin", "", "Belirtilen yönetici hesabına süper yönetici yetkisi ver")
+	revokeSuperAdminFlag := flag.String("revoke-super-admin", "", "Belirtilen hesabın süper yönetici yetkisini kaldır")
 	flag.Parse()
 
+	if *newFlag != "" {
+		dir, err := migrations.SQLDir()
+		if err != nil {
+			logs.SLog.Fatalf("SQL migrasyon dosyaları oluşturulamadı: %v", err)
+		}
+		upPath, downPath, err := migrations.NewSQLMigration(dir, *newFlag)
+		if err != nil {
+			logs.SLog.Fatalf("SQL migrasyon dosyaları oluşturulamadı: %v", err)
+		}
+		fmt.Println("  oluşturuldu:", upPath)
+		fmt.Println("  oluşturuldu:", downPath)
+		return
+	}
+
 	configs.InitDB()
 	defer configs.CloseDB()
 
 	db := configs.GetDB()
 
+	if *protectFlag != "" || *unprotectFlag != "" {
+		account, protected := *protectFlag, true
+		if *unprotectFlag != "" {
+			account, protected = *unprotectFlag, false
+		}
+		if err := database.SetUserProtection(db, account, protected); err != nil {
+			logs.SLog.Fatalf("Koruma durumu güncellenemedi: %v", err)
+		}
+		return
+	}
+
+	if *grantSuperAdminFlag != "" || *revokeSuperAdminFlag != "" {
+		account, superAdmin := *grantSuperAdminFlag, true
+		if *revokeSuperAdminFlag != "" {
+			account, superAdmin = *revokeSuperAdminFlag, false
+		}
+		if err := database.SetUserSuperAdmin(db, account, superAdmin); err != nil {
+			logs.SLog.Fatalf("Süper yönetici durumu güncellenemedi: %v", err)
+		}
+		return
+	}
+
+	if *fakeFlag != "" {
+		runFactories(db, *fakeFlag, *fakeSeedFlag, *fakeBatchFlag)
+		return
+	}
+
+	if *exportFlag != "" || *importFlag != "" {
+		opts := archiveOptions(db, *tablesFlag, *excludeTablesFlag, *anonymizeFlag, *importTruncateFlag)
+		if *exportFlag != "" {
+			runExport(db, *exportFlag, opts)
+		} else {
+			runImport(db, *importFlag, opts)
+		}
+		return
+	}
+
+	if *seedersFlag {
+		printSeederStatus(database.NewSeederRunner(db))
+		return
+	}
+
+	if *statusFlag || *upFlag || *downFlag != 0 || *toFlag != "" {
+		runMigrator(database.NewMigrator(db), *statusFlag, *upFlag, *downFlag, *toFlag)
+		return
+	}
+
 	logs.SLog.Info("Veritabanı başlatma işlemi çalıştırılıyor...")
-	database.Initialize(db, *migrateFlag, *seedFlag)
+	database.Initialize(db, *migrateFlag, seedFlag.enabled, seedFlag.names...)
 
 	logs.SLog.Info("Veritabanı başlatma işlemi tamamlandı.")
 }
+
+type seedList struct {
+	enabled bool
+	names   []string
+}
+
+func (s *seedList) String() string {
+	return strings.Join(s.names, ",")
+}
+
+func (s *seedList) IsBoolFlag() bool {
+	return true
+}
+
+func (s *seedList) Set(value string) error {
+	switch value {
+	case "true", "all":
+		s.enabled = true
+		return nil
+	case "false":
+		s.enabled = false
+		return nil
+	}
+
+	s.enabled = true
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			s.names = append(s.names, name)
+		}
+	}
+	return nil
+}
+
+func runFactories(db *gorm.DB, spec string, seed int64, batchSize int) {
+	if env.IsProduction() {
+		logs.SLog.Fatal("Sahte veri üretimi production ortamında çalıştırılamaz.")
+	}
+	counts, err := factories.ParseCounts(spec)
+	if err != nil {
+		logs.SLog.Fatalf("Geçersiz -fake değeri: %v", err)
+	}
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	started := time.Now()
+	if err := factories.Run(db, counts, seed, batchSize); err != nil {
+		logs.SLog.Fatalf("Sahte veri üretimi başarısız oldu: %v", err)
+	}
+	logs.SLog.Infof("Sahte veri üretimi %s içinde tamamlandı. Aynı verileri üretmek için: -fake-seed=%d", time.Since(started).Round(time.Millisecond), seed)
+}
+
+func archiveOptions(db *gorm.DB, tables, excludeTables, anonymize string, truncate bool) archive.Options {
+	rules, err := archive.ParseRules(anonymize)
+	if err != nil {
+		logs.SLog.Fatalf("Geçersiz -anonymize değeri: %v", err)
+	}
+	if truncate && env.IsProduction() {
+		logs.SLog.Fatal("-import-truncate production ortamında kullanılamaz.")
+	}
+
+	opts := archive.Options{
+		Include:  splitNames(tables),
+		Exclude:  splitNames(excludeTables),
+		Rules:    rules,
+		Truncate: truncate,
+	}
+	if statuses, err := database.NewMigrator(db).Status(); err == nil {
+		for _, status := range statuses {
+			if status.Applied && !status.Missing {
+				opts.Schema = status.ID
+			}
+		}
+	}
+	return opts
+}
+
+func runExport(db *gorm.DB, path string, opts archive.Options) {
+	file, err := archive.Create(path)
+	if err != nil {
+		logs.SLog.Fatalf("Arşiv dosyası oluşturulamadı: %v", err)
+	}
+
+	started := time.Now()
+	results, err := archive.Export(db, file, opts)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(path)
+		logs.SLog.Fatalf("Dışa aktarma başarısız oldu: %v", err)
+	}
+
+	total := 0
+	for _, result := range results {
+		total += result.Rows
+	}
+	logs.SLog.Infof("%d tablodan %d kayıt %s içinde %s dosyasına aktarıldı.", len(results), total, time.Since(started).Round(time.Millisecond), path)
+}
+
+func runImport(db *gorm.DB, path string, opts archive.Options) {
+	file, err := archive.Open(path)
+	if err != nil {
+		logs.SLog.Fatalf("Arşiv dosyası açılamadı: %v", err)
+	}
+	defer file.Close()
+
+	started := time.Now()
+	results, err := archive.Import(db, file, opts)
+	if err != nil {
+		logs.SLog.Fatalf("İçe aktarma başarısız oldu, hiçbir değişiklik uygulanmadı: %v", err)
+	}
+
+	for _, result := range results {
+		fmt.Printf("  %-15s %d eklendi, %d atlandı\n", result.Name, result.Rows, result.Skipped)
+	}
+	logs.SLog.Infof("İçe aktarma %s içinde tamamlandı.", time.Since(started).Round(time.Millisecond))
+}
+
+func splitNames(value string) []string {
+	var names []string
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func runMigrator(migrator *database.Migrator, status, up bool, down int, to string) {
+	var (
+		count int
+		err   error
+	)
+	switch {
+	case status:
+		printMigrationStatus(migrator)
+		return
+	case up:
+		count, err = migrator.Up()
+	case down != 0:
+		count, err = migrator.Down(down)
+	default:
+		count, err = migrator.To(to)
+	}
+	if err != nil {
+		logs.SLog.Fatalf("Migrasyon işlemi başarısız oldu (%d adım tamamlandı): %v", count, err)
+	}
+	logs.SLog.Infof("Migrasyon işlemi tamamlandı: %d adım çalıştırıldı.", count)
+}
+
+func printMigrationStatus(migrator *database.Migrator) {
+	statuses, err := migrator.Status()
+	if err != nil {
+		logs.SLog.Fatalf("Migrasyon durumu alınamadı: %v", err)
+	}
+
+	pending, modified := 0, 0
+	for _, status := range statuses {
+		kind := "go"
+		if status.SQL {
+			kind = "sql"
+		}
+		switch {
+		case status.Modified:
+			modified++
+			fmt.Printf("  [!] %s  [%s] (uygulandıktan sonra değiştirilmiş: %s)\n", status.ID, kind, status.AppliedAt.Format("2006-01-02 15:04:05"))
+		case status.Missing:
+			fmt.Printf("  [?] %s  (uygulanmış, ancak kodda bulunamadı: %s)\n", status.ID, status.AppliedAt.Format("2006-01-02 15:04:05"))
+		case status.Applied:
+			fmt.Printf("  [x] %s  [%s] (%s)\n", status.ID, kind, status.AppliedAt.Format("2006-01-02 15:04:05"))
+		default:
+			pending++
+			fmt.Printf("  [ ] %s  [%s]\n", status.ID, kind)
+		}
+	}
+	fmt.Printf("\n%d migrasyon, %d bekleyen, %d değiştirilmiş.\n", len(statuses), pending, modified)
+}
+
+func printSeederStatus(runner *database.SeederRunner) {
+	statuses, err := runner.Status()
+	if err != nil {
+		logs.SLog.Fatalf("Seeder durumu alınamadı: %v", err)
+	}
+
+	fmt.Printf("Ortam: %s\n\n", runner.Env())
+	for _, status := range statuses {
+		mark := " "
+		if status.RunAt != nil {
+			mark = "x"
+		}
+		details := "envs: " + strings.Join(status.Envs, ",")
+		if len(status.DependsOn) > 0 {
+			details += ", bağımlılıklar: " + strings.Join(status.DependsOn, ",")
+		}
+		if status.Once {
+			details += ", tek seferlik"
+		}
+		if !status.Allowed {
+			details += ", bu ortamda çalışmaz"
+		}
+		if status.RunAt != nil {
+			details += ", son çalışma: " + status.RunAt.Format("2006-01-02 15:04:05")
+		}
+		fmt.Printf("  [%s] %s  (%s)\n", mark, status.Name, details)
+	}
+}
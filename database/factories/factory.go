@@ -0,0 +1,84 @@
+package factories
+
+import (
+	"fmt"
+
+	"zatrano/pkg/logs"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const DefaultBatchSize = 500
+
+type Inserter interface {
+	Insert(db *gorm.DB, count, batchSize int) (int64, error)
+}
+
+type Factory[T any] struct {
+	faker       *Faker
+	define      func(f *Faker, seq int) T
+	afterInsert func(tx *gorm.DB, planned []T) error
+	sequence    int
+}
+
+func New[T any](seed int64, define func(f *Faker, seq int) T) *Factory[T] {
+	return &Factory[T]{faker: NewFaker(seed), define: define}
+}
+
+func (f *Factory[T]) StartAt(sequence int) *Factory[T] {
+	f.sequence = sequence
+	return f
+}
+
+func (f *Factory[T]) AfterInsert(fn func(tx *gorm.DB, planned []T) error) *Factory[T] {
+	f.afterInsert = fn
+	return f
+}
+
+func (f *Factory[T]) Make() T {
+	f.sequence++
+	return f.define(f.faker, f.sequence)
+}
+
+func (f *Factory[T]) MakeMany(count int) []T {
+	items := make([]T, count)
+	for i := range items {
+		items[i] = f.Make()
+	}
+	return items
+}
+
+func (f *Factory[T]) Insert(db *gorm.DB, count, batchSize int) (int64, error) {
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+
+	var inserted int64
+	for done := 0; done < count; {
+		size := batchSize
+		if remaining := count - done; remaining < size {
+			size = remaining
+		}
+
+		batch := f.MakeMany(size)
+		planned := append([]T(nil), batch...)
+		err := db.Transaction(func(tx *gorm.DB) error {
+			result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&batch)
+			if result.Error != nil {
+				return result.Error
+			}
+			inserted += result.RowsAffected
+			if f.afterInsert != nil {
+				return f.afterInsert(tx, planned)
+			}
+			return nil
+		})
+		if err != nil {
+			return inserted, fmt.Errorf("%d. kayıttan itibaren toplu ekleme başarısız: %w", done+1, err)
+		}
+		done += size
+		logs.SLog.Infof(" -> %d/%d kayıt hazırlandı (%d eklendi).", done, count, inserted)
+	}
+	return inserted, nil
+}
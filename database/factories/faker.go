@@ -0,0 +1,88 @@
+package factories
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+var firstNames = []string{
+	"Ahmet", "Mehmet", "Mustafa", "Ali", "Hüseyin", "Hasan", "İbrahim", "İsmail", "Osman", "Yusuf",
+	"Murat", "Ömer", "Ramazan", "Halil", "Süleyman", "Abdullah", "Emre", "Burak", "Serkan", "Çağrı",
+	"Oğuz", "Barış", "Gökhan", "Uğur", "Tolga", "Kerem", "Eren", "Berk", "Can", "Onur",
+	"Ayşe", "Fatma", "Emine", "Hatice", "Zeynep", "Elif", "Meryem", "Şerife", "Zehra", "Sultan",
+	"Hanife", "Merve", "Özlem", "Yasemin", "Gülşen", "Büşra", "Esra", "Ebru", "Derya", "Seda",
+	"Gizem", "Tuğba", "Çiğdem", "Nur", "Damla", "İrem", "Sıla", "Şeyma", "Pınar", "Ülkü",
+}
+
+var lastNames = []string{
+	"Yılmaz", "Kaya", "Demir", "Şahin", "Çelik", "Yıldız", "Yıldırım", "Öztürk", "Aydın", "Özdemir",
+	"Arslan", "Doğan", "Kılıç", "Aslan", "Çetin", "Kara", "Koç", "Kurt", "Özkan", "Şimşek",
+	"Polat", "Özcan", "Korkmaz", "Çakır", "Erdoğan", "Yavuz", "Can", "Acar", "Şen", "Aktaş",
+	"Güler", "Yalçın", "Güneş", "Bozkurt", "Bulut", "Keskin", "Ünal", "Turan", "Gül", "Özer",
+	"Işık", "Kaplan", "Avcı", "Sarı", "Tekin", "Taş", "Köse", "Yüksel", "Ateş", "Aksoy",
+}
+
+var emailDomains = []string{"example.com", "example.com.tr", "example.org", "example.net"}
+
+var asciiReplacer = strings.NewReplacer(
+	"ç", "c", "Ç", "c", "ğ", "g", "Ğ", "g", "ı", "i", "I", "i", "İ", "i",
+	"ö", "o", "Ö", "o", "ş", "s", "Ş", "s", "ü", "u", "Ü", "u",
+)
+
+type Faker struct {
+	rng *rand.Rand
+	now time.Time
+}
+
+func NewFaker(seed int64) *Faker {
+	return &Faker{rng: rand.New(rand.NewSource(seed)), now: time.Now()}
+}
+
+func (f *Faker) Intn(n int) int {
+	return f.rng.Intn(n)
+}
+
+func (f *Faker) Chance(percent int) bool {
+	return f.rng.Intn(100) < percent
+}
+
+func (f *Faker) Pick(values []string) string {
+	return values[f.rng.Intn(len(values))]
+}
+
+func (f *Faker) FirstName() string {
+	return f.Pick(firstNames)
+}
+
+func (f *Faker) LastName() string {
+	return f.Pick(lastNames)
+}
+
+func (f *Faker) Phone() string {
+	return fmt.Sprintf("05%02d %03d %02d %02d", 30+f.rng.Intn(27), f.rng.Intn(1000), f.rng.Intn(100), f.rng.Intn(100))
+}
+
+func (f *Faker) Email(local string) string {
+	return local + "@" + f.Pick(emailDomains)
+}
+
+func (f *Faker) PastTime(maxAge time.Duration) time.Time {
+	return f.now.Add(-time.Duration(f.rng.Int63n(int64(maxAge)))).Truncate(time.Second)
+}
+
+func (f *Faker) TimeAfter(start time.Time) time.Time {
+	span := f.now.Sub(start)
+	if span <= 0 {
+		return start
+	}
+	return start.Add(time.Duration(f.rng.Int63n(int64(span)))).Truncate(time.Second)
+}
+
+func Slug(parts ...string) string {
+	for i, part := range parts {
+		parts[i] = strings.ToLower(asciiReplacer.Replace(part))
+	}
+	return strings.Join(parts, ".")
+}
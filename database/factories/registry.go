@@ -0,0 +1,85 @@
+package factories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"zatrano/database/seeders"
+	"zatrano/models"
+	"zatrano/pkg/logs"
+
+	"gorm.io/gorm"
+)
+
+var ErrUnknownFactory = errors.New("factory kayıtlarda bulunamadı")
+
+var registry = map[string]func(db *gorm.DB, seed int64) (Inserter, error){
+	"users": newUserInserter,
+}
+
+func Names() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func ParseCounts(spec string) (map[string]int, error) {
+	counts := make(map[string]int)
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, rawCount, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("geçersiz factory tanımı %q (beklenen: ad=adet)", part)
+		}
+		name = strings.TrimSpace(name)
+		if _, exists := registry[name]; !exists {
+			return nil, fmt.Errorf("%w: %s (mevcut: %s)", ErrUnknownFactory, name, strings.Join(Names(), ", "))
+		}
+		count, err := strconv.Atoi(strings.TrimSpace(rawCount))
+		if err != nil || count <= 0 {
+			return nil, fmt.Errorf("%s için geçersiz adet: %q", name, rawCount)
+		}
+		counts[name] = count
+	}
+	if len(counts) == 0 {
+		return nil, errors.New("en az bir factory belirtilmelidir (örnek: users=5000)")
+	}
+	return counts, nil
+}
+
+func Run(db *gorm.DB, counts map[string]int, seed int64, batchSize int) error {
+	var systemUser models.User
+	if err := db.Where("account = ?", seeders.GetSystemUserConfig().Account).First(&systemUser).Error; err != nil {
+		return errors.New("sahte veriler için sistem kullanıcısı bulunamadı, önce -seed çalıştırılmalı: " + err.Error())
+	}
+	db = db.WithContext(context.WithValue(context.Background(), "user_id", systemUser.ID))
+
+	for _, name := range Names() {
+		count, ok := counts[name]
+		if !ok {
+			continue
+		}
+
+		inserter, err := registry[name](db, seed)
+		if err != nil {
+			return fmt.Errorf("%s factory hazırlanamadı: %w", name, err)
+		}
+		logs.SLog.Infof("%s için %d sahte kayıt oluşturuluyor (seed: %d)...", name, count, seed)
+		inserted, err := inserter.Insert(db, count, batchSize)
+		if err != nil {
+			return fmt.Errorf("%s sahte verileri eklenemedi: %w", name, err)
+		}
+		logs.SLog.Infof("%s: %d kayıt eklendi.", name, inserted)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package factories
+
+import (
+	"fmt"
+	"time"
+
+	"zatrano/database/migrations"
+	"zatrano/models"
+
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+const (
+	FakeUserPassword   = "fake1234"
+	fakeAccountDomain  = "fake"
+	fakeUserMaxAge     = 2 * 365 * 24 * time.Hour
+	fakeDashboardShare = 10
+	fakeActiveShare    = 85
+)
+
+func NewUserFactory(seed int64, organizationID uint, passwordHash string) *Factory[models.User] {
+	return New(seed, func(f *Faker, seq int) models.User {
+		first, last := f.FirstName(), f.LastName()
+		local := Slug(first, last)
+
+		userType := models.Panel
+		if f.Chance(fakeDashboardShare) {
+			userType = models.Dashboard
+		}
+		createdAt := f.PastTime(fakeUserMaxAge)
+
+		user := models.User{
+			Name:           first + " " + last,
+			Account:        fmt.Sprintf("%s.%d@%s", local, seq, fakeAccountDomain),
+			Password:       passwordHash,
+			Status:         f.Chance(fakeActiveShare),
+			Type:           userType,
+			OrganizationID: organizationID,
+		}
+		user.CreatedAt = createdAt
+		user.UpdatedAt = f.TimeAfter(createdAt)
+		if f.Chance(70) {
+			user.Email = f.Email(local)
+		}
+		if f.Chance(60) {
+			user.Phone = f.Phone()
+		}
+		return user
+	})
+}
+
+func newUserInserter(db *gorm.DB, seed int64) (Inserter, error) {
+	organizationID, err := migrations.EnsureDefaultOrganization(db)
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(FakeUserPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+
+	var existing int64
+	if err := db.Model(&models.User{}).Where("account LIKE ?", "%@"+fakeAccountDomain).Count(&existing).Error; err != nil {
+		return nil, fmt.Errorf("mevcut sahte kullanıcılar sayılamadı: %w", err)
+	}
+	return NewUserFactory(seed, organizationID, string(hash)).StartAt(int(existing)).AfterInsert(restoreInactiveUsers), nil
+}
+
+func restoreInactiveUsers(tx *gorm.DB, planned []models.User) error {
+	var accounts []string
+	for _, user := range planned {
+		if !user.Status {
+			accounts = append(accounts, user.Account)
+		}
+	}
+	if len(accounts) == 0 {
+		return nil
+	}
+	return tx.Model(&models.User{}).Where("account IN ?", accounts).UpdateColumn("status", false).Error
+}
@@ -0,0 +1,44 @@
+package migrations
+
+import (
+	"errors"
+	"time"
+	"zatrano/pkg/logs"
+
+	"gorm.io/gorm"
+)
+
+type auditLogTableV1 struct {
+	ID             uint      `gorm:"primarykey"`
+	ActorID        *uint     `gorm:"index"`
+	OrganizationID *uint     `gorm:"index"`
+	Action         string    `gorm:"size:20;not null;index"`
+	EntityType     string    `gorm:"size:50;not null;index:idx_audit_logs_entity,priority:1"`
+	EntityID       uint      `gorm:"not null;index:idx_audit_logs_entity,priority:2"`
+	Changes        string    `gorm:"type:text"`
+	IP             string    `gorm:"size:45"`
+	RequestID      string    `gorm:"size:64;index"`
+	CreatedAt      time.Time `gorm:"not null;index"`
+}
+
+func (auditLogTableV1) TableName() string { return "audit_logs" }
+
+func MigrateAuditLogsTable(db *gorm.DB) error {
+	logs.SLog.Info("AuditLog tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&auditLogTableV1{}); err != nil {
+		return errors.New("AuditLog tablosu migrate edilemedi: " + err.Error())
+	}
+
+	logs.SLog.Info("AuditLog tablosu migrate işlemi tamamlandı.")
+	return nil
+}
+
+func DropAuditLogsTable(db *gorm.DB) error {
+	logs.SLog.Info("AuditLog tablosu kaldırılıyor...")
+	if err := db.Migrator().DropTable(&auditLogTableV1{}); err != nil {
+		return errors.New("AuditLog tablosu kaldırılamadı: " + err.Error())
+	}
+
+	logs.SLog.Info("AuditLog tablosu kaldırıldı.")
+	return nil
+}
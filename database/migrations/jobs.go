@@ -0,0 +1,49 @@
+package migrations
+
+import (
+	"errors"
+	"time"
+	"zatrano/pkg/logs"
+
+	"gorm.io/gorm"
+)
+
+type jobTableV1 struct {
+	ID          uint       `gorm:"primarykey"`
+	Type        string     `gorm:"size:100;not null;index"`
+	Payload     string     `gorm:"type:text;not null"`
+	Status      string     `gorm:"size:20;not null;default:'pending';index:idx_jobs_status_run_at,priority:1"`
+	Attempts    int        `gorm:"not null;default:0"`
+	MaxAttempts int        `gorm:"not null;default:5"`
+	RunAt       time.Time  `gorm:"not null;index:idx_jobs_status_run_at,priority:2"`
+	LockedBy    string     `gorm:"size:100"`
+	LockedAt    *time.Time `gorm:"index"`
+	LastError   string     `gorm:"type:text"`
+	CompletedAt *time.Time
+	FailedAt    *time.Time
+	CreatedBy   *uint
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (jobTableV1) TableName() string { return "jobs" }
+
+func MigrateJobsTable(db *gorm.DB) error {
+	logs.SLog.Info("Job tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&jobTableV1{}); err != nil {
+		return errors.New("Job tablosu migrate edilemedi: " + err.Error())
+	}
+
+	logs.SLog.Info("Job tablosu migrate işlemi tamamlandı.")
+	return nil
+}
+
+func DropJobsTable(db *gorm.DB) error {
+	logs.SLog.Info("Job tablosu kaldırılıyor...")
+	if err := db.Migrator().DropTable(&jobTableV1{}); err != nil {
+		return errors.New("Job tablosu kaldırılamadı: " + err.Error())
+	}
+
+	logs.SLog.Info("Job tablosu kaldırıldı.")
+	return nil
+}
@@ -0,0 +1,39 @@
+package migrations
+
+import (
+	"errors"
+	"time"
+	"zatrano/pkg/logs"
+
+	"gorm.io/gorm"
+)
+
+type loginEventTableV1 struct {
+	ID        uint      `gorm:"primarykey"`
+	UserID    uint      `gorm:"not null;index"`
+	IP        string    `gorm:"size:45"`
+	UserAgent string    `gorm:"size:255"`
+	CreatedAt time.Time `gorm:"not null;index"`
+}
+
+func (loginEventTableV1) TableName() string { return "login_events" }
+
+func MigrateLoginEventsTable(db *gorm.DB) error {
+	logs.SLog.Info("LoginEvent tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&loginEventTableV1{}); err != nil {
+		return errors.New("LoginEvent tablosu migrate edilemedi: " + err.Error())
+	}
+
+	logs.SLog.Info("LoginEvent tablosu migrate işlemi tamamlandı.")
+	return nil
+}
+
+func DropLoginEventsTable(db *gorm.DB) error {
+	logs.SLog.Info("LoginEvent tablosu kaldırılıyor...")
+	if err := db.Migrator().DropTable(&loginEventTableV1{}); err != nil {
+		return errors.New("LoginEvent tablosu kaldırılamadı: " + err.Error())
+	}
+
+	logs.SLog.Info("LoginEvent tablosu kaldırıldı.")
+	return nil
+}
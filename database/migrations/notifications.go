@@ -0,0 +1,46 @@
+package migrations
+
+import (
+	"errors"
+	"time"
+	"zatrano/pkg/logs"
+
+	"gorm.io/gorm"
+)
+
+type notificationTableV1 struct {
+	ID        uint       `gorm:"primarykey"`
+	UserID    uint       `gorm:"not null;index:idx_notifications_user_created,priority:1"`
+	Title     string     `gorm:"size:150;not null"`
+	Body      string     `gorm:"type:text"`
+	Link      string     `gorm:"size:255"`
+	Level     string     `gorm:"size:20;not null;default:'info'"`
+	ReadAt    *time.Time `gorm:"index"`
+	CreatedAt time.Time  `gorm:"not null;index:idx_notifications_user_created,priority:2"`
+}
+
+func (notificationTableV1) TableName() string { return "notifications" }
+
+func MigrateNotificationsTable(db *gorm.DB) error {
+	logs.SLog.Info("Notification tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&notificationTableV1{}); err != nil {
+		return errors.New("Notification tablosu migrate edilemedi: " + err.Error())
+	}
+
+	if err := db.Exec("CREATE INDEX IF NOT EXISTS idx_notifications_unread ON notifications (user_id) WHERE read_at IS NULL").Error; err != nil {
+		return errors.New("okunmamış bildirim indeksi oluşturulamadı: " + err.Error())
+	}
+
+	logs.SLog.Info("Notification tablosu migrate işlemi tamamlandı.")
+	return nil
+}
+
+func DropNotificationsTable(db *gorm.DB) error {
+	logs.SLog.Info("Notification tablosu kaldırılıyor...")
+	if err := db.Migrator().DropTable(&notificationTableV1{}); err != nil {
+		return errors.New("Notification tablosu kaldırılamadı: " + err.Error())
+	}
+
+	logs.SLog.Info("Notification tablosu kaldırıldı.")
+	return nil
+}
@@ -0,0 +1,53 @@
+package migrations
+
+import (
+	"errors"
+	"zatrano/pkg/logs"
+
+	"gorm.io/gorm"
+)
+
+const renamedBackfillID = "0002_organizations_backfill"
+
+func BackfillOrganizationIDs(db *gorm.DB) error {
+	// The backfill used to run as 0002_organizations_backfill; databases that applied it are already filled.
+	result := db.Exec("DELETE FROM schema_migrations WHERE id = ?", renamedBackfillID)
+	if result.Error != nil {
+		return errors.New("eski organizasyon doldurma kaydı silinemedi: " + result.Error.Error())
+	}
+	if result.RowsAffected > 0 {
+		logs.SLog.Infof("%s daha önce uygulanmış, organization_id değerleri zaten dolu.", renamedBackfillID)
+		return nil
+	}
+
+	var missing int64
+	if err := db.Table("users").Where("organization_id IS NULL").Count(&missing).Error; err != nil {
+		return errors.New("organizasyonu olmayan kullanıcılar sayılamadı: " + err.Error())
+	}
+	if missing > 0 {
+		organizationID, err := EnsureDefaultOrganization(db)
+		if err != nil {
+			return err
+		}
+
+		logs.SLog.Infof("Mevcut kullanıcılar ve denetim kayıtları varsayılan organizasyona (ID: %d) atanıyor...", organizationID)
+		if err := db.Exec("UPDATE users SET organization_id = ? WHERE organization_id IS NULL", organizationID).Error; err != nil {
+			return errors.New("kullanıcı organizasyonları doldurulamadı: " + err.Error())
+		}
+		if err := db.Exec("UPDATE audit_logs SET organization_id = ? WHERE organization_id IS NULL", organizationID).Error; err != nil {
+			return errors.New("denetim kayıtlarının organizasyonları doldurulamadı: " + err.Error())
+		}
+	}
+
+	if db.Dialector.Name() == "postgres" {
+		if err := db.Exec("ALTER TABLE users ALTER COLUMN organization_id SET NOT NULL").Error; err != nil {
+			return errors.New("users.organization_id zorunlu yapılamadı: " + err.Error())
+		}
+	}
+	return nil
+}
+
+func keepOrganizationIDs(db *gorm.DB) error {
+	logs.SLog.Info("Doldurulan organization_id değerleri geri alınmıyor; kolonlar ilgili tablolarla birlikte kaldırılır.")
+	return nil
+}
@@ -0,0 +1,62 @@
+package migrations
+
+import (
+	"errors"
+	"time"
+	"zatrano/models"
+	"zatrano/pkg/logs"
+
+	"gorm.io/gorm"
+)
+
+type organizationTableV1 struct {
+	ID        uint   `gorm:"primarykey"`
+	Name      string `gorm:"size:150;not null;uniqueIndex"`
+	Status    bool   `gorm:"not null;default:true;index"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (organizationTableV1) TableName() string { return "organizations" }
+
+func MigrateOrganizationsTable(db *gorm.DB) error {
+	logs.SLog.Info("Organization tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&organizationTableV1{}); err != nil {
+		return errors.New("Organization tablosu migrate edilemedi: " + err.Error())
+	}
+
+	if _, err := EnsureDefaultOrganization(db); err != nil {
+		return err
+	}
+
+	logs.SLog.Info("Organization tablosu migrate işlemi tamamlandı.")
+	return nil
+}
+
+func DropOrganizationsTable(db *gorm.DB) error {
+	logs.SLog.Info("Organization tablosu kaldırılıyor...")
+	if err := db.Migrator().DropTable(&organizationTableV1{}); err != nil {
+		return errors.New("Organization tablosu kaldırılamadı: " + err.Error())
+	}
+
+	logs.SLog.Info("Organization tablosu kaldırıldı.")
+	return nil
+}
+
+func EnsureDefaultOrganization(db *gorm.DB) (uint, error) {
+	var organization organizationTableV1
+	err := db.Order("id").Limit(1).Find(&organization).Error
+	if err != nil {
+		return 0, errors.New("varsayılan organizasyon kontrol edilemedi: " + err.Error())
+	}
+	if organization.ID != 0 {
+		return organization.ID, nil
+	}
+
+	organization = organizationTableV1{Name: models.DefaultOrganizationName, Status: true}
+	if err := db.Create(&organization).Error; err != nil {
+		return 0, errors.New("varsayılan organizasyon oluşturulamadı: " + err.Error())
+	}
+	logs.SLog.Infof("Varsayılan organizasyon oluşturuldu: %s (ID: %d)", organization.Name, organization.ID)
+	return organization.ID, nil
+}
@@ -0,0 +1,26 @@
+package migrations
+
+import "gorm.io/gorm"
+
+type Migration struct {
+	ID       string
+	Up       func(tx *gorm.DB) error
+	Down     func(tx *gorm.DB) error
+	Checksum string
+}
+
+func All() []Migration {
+	return []Migration{
+		{ID: "0001_enable_search_extensions", Up: enableSearchExtensions, Down: keepSearchExtensions},
+		{ID: "0002_create_organizations", Up: MigrateOrganizationsTable, Down: DropOrganizationsTable},
+		{ID: "0003_create_users", Up: MigrateUsersTable, Down: DropUsersTable},
+		{ID: "0004_create_login_events", Up: MigrateLoginEventsTable, Down: DropLoginEventsTable},
+		{ID: "0005_create_settings", Up: MigrateSettingsTable, Down: DropSettingsTable},
+		{ID: "0006_create_audit_logs", Up: MigrateAuditLogsTable, Down: DropAuditLogsTable},
+		{ID: "0007_create_notifications", Up: MigrateNotificationsTable, Down: DropNotificationsTable},
+		{ID: "0009_add_users_must_change_password", Up: AddUsersMustChangePassword, Down: DropUsersMustChangePassword},
+		{ID: "0010_create_jobs", Up: MigrateJobsTable, Down: DropJobsTable},
+		{ID: "0011_create_users_search_indexes", Up: CreateUserSearchIndexes, Down: DropUserSearchIndexes},
+		{ID: "0012_backfill_organization_ids", Up: BackfillOrganizationIDs, Down: keepOrganizationIDs},
+	}
+}
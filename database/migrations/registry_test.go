@@ -0,0 +1,28 @@
+package migrations
+
+import "testing"
+
+func TestLoadRejectsDuplicateNumbers(t *testing.T) {
+	if _, err := Load(); err != nil {
+		t.Fatalf("kayıtlı migrasyonlar geçerli olmalı: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		ids  []string
+		ok   bool
+	}{
+		{name: "unique", ids: []string{"0001_a", "0002_b", "20240101120000_c"}, ok: true},
+		{name: "same number", ids: []string{"0002_create_organizations", "0002_organizations_backfill"}},
+		{name: "same number different padding", ids: []string{"0002_a", "2_b"}},
+		{name: "same id", ids: []string{"0003_a", "0003_a"}},
+		{name: "no number", ids: []string{"create_users"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkIDNumbers(tt.ids); (err == nil) != tt.ok {
+				t.Fatalf("beklenen geçerlilik %v, dönen hata: %v", tt.ok, err)
+			}
+		})
+	}
+}
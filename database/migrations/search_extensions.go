@@ -0,0 +1,41 @@
+package migrations
+
+import (
+	"zatrano/pkg/logs"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var searchExtensions = []string{"unaccent", "pg_trgm"}
+
+func EnableSearchExtensions(db *gorm.DB) {
+	if db.Dialector.Name() != "postgres" {
+		logs.SLog.Info("Arama eklentileri yalnızca PostgreSQL için kurulur, atlanıyor.")
+		return
+	}
+
+	for _, extension := range searchExtensions {
+		savePoint := "ext_" + extension
+		db.SavePoint(savePoint)
+		if err := db.Exec("CREATE EXTENSION IF NOT EXISTS " + extension).Error; err != nil {
+			db.RollbackTo(savePoint)
+			logs.Log.Warn("Arama eklentisi kurulamadı, arama bu eklenti olmadan çalışacak",
+				zap.String("extension", extension),
+				zap.Error(err),
+			)
+			continue
+		}
+		logs.SLog.Infof("Arama eklentisi hazır: %s", extension)
+	}
+}
+
+func enableSearchExtensions(db *gorm.DB) error {
+	EnableSearchExtensions(db)
+	return nil
+}
+
+func keepSearchExtensions(db *gorm.DB) error {
+	logs.SLog.Info("Arama eklentileri başka şemalar tarafından kullanılabileceği için kaldırılmıyor.")
+	return nil
+}
@@ -0,0 +1,82 @@
+package migrations
+
+import (
+	"errors"
+
+	"zatrano/pkg/logs"
+
+	"gorm.io/gorm"
+)
+
+const searchFoldFunctionV1 = "search_fold"
+
+var userSearchColumnsV1 = []string{"name", "account"}
+
+func extensionSchema(db *gorm.DB, extension string) (string, error) {
+	var schemas []string
+	err := db.Raw("SELECT n.nspname FROM pg_extension e JOIN pg_namespace n ON n.oid = e.extnamespace WHERE e.extname = ?", extension).
+		Scan(&schemas).Error
+	if err != nil || len(schemas) == 0 {
+		return "", err
+	}
+	return schemas[0], nil
+}
+
+func CreateUserSearchIndexes(db *gorm.DB) error {
+	if db.Dialector.Name() != "postgres" {
+		logs.SLog.Info("Trigram arama indeksleri yalnızca PostgreSQL için oluşturulur, atlanıyor.")
+		return nil
+	}
+
+	trigramSchema, err := extensionSchema(db, "pg_trgm")
+	if err != nil {
+		return errors.New("pg_trgm eklentisi kontrol edilemedi: " + err.Error())
+	}
+	if trigramSchema == "" {
+		logs.SLog.Warn("pg_trgm eklentisi kurulu değil, trigram arama indeksleri oluşturulmadı.")
+		return nil
+	}
+	unaccentSchema, err := extensionSchema(db, "unaccent")
+	if err != nil {
+		return errors.New("unaccent eklentisi kontrol edilemedi: " + err.Error())
+	}
+
+	body := "lower(translate(value, 'IİıÇçĞğÖöŞşÜüÂâÎîÛû', 'iiiccggoossuuaaiiuu'))"
+	if unaccentSchema != "" {
+		body = unaccentSchema + ".unaccent('" + unaccentSchema + ".unaccent'::regdictionary, " + body + ")"
+	}
+	logs.SLog.Info("Arama katlama fonksiyonu oluşturuluyor...")
+	createFunction := "CREATE OR REPLACE FUNCTION " + searchFoldFunctionV1 + "(value text) RETURNS text " +
+		"LANGUAGE sql IMMUTABLE STRICT PARALLEL SAFE AS $$ SELECT " + body + " $$"
+	if err := db.Exec(createFunction).Error; err != nil {
+		return errors.New("arama katlama fonksiyonu oluşturulamadı: " + err.Error())
+	}
+
+	for _, column := range userSearchColumnsV1 {
+		name := "idx_users_" + column + "_trgm"
+		logs.SLog.Infof("Trigram arama indeksi oluşturuluyor: %s", name)
+		query := "CREATE INDEX IF NOT EXISTS " + name + " ON users USING gin (" +
+			searchFoldFunctionV1 + "(" + column + ") " + trigramSchema + ".gin_trgm_ops)"
+		if err := db.Exec(query).Error; err != nil {
+			return errors.New("trigram arama indeksi oluşturulamadı: " + err.Error())
+		}
+	}
+	return nil
+}
+
+func DropUserSearchIndexes(db *gorm.DB) error {
+	if db.Dialector.Name() != "postgres" {
+		return nil
+	}
+
+	logs.SLog.Info("Trigram arama indeksleri kaldırılıyor...")
+	for _, column := range userSearchColumnsV1 {
+		if err := db.Exec("DROP INDEX IF EXISTS idx_users_" + column + "_trgm").Error; err != nil {
+			return errors.New("trigram arama indeksi kaldırılamadı: " + err.Error())
+		}
+	}
+	if err := db.Exec("DROP FUNCTION IF EXISTS " + searchFoldFunctionV1 + "(text)").Error; err != nil {
+		return errors.New("arama katlama fonksiyonu kaldırılamadı: " + err.Error())
+	}
+	return nil
+}
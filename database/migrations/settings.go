@@ -0,0 +1,39 @@
+package migrations
+
+import (
+	"errors"
+	"time"
+	"zatrano/pkg/logs"
+
+	"gorm.io/gorm"
+)
+
+type settingTableV1 struct {
+	ID        uint   `gorm:"primarykey"`
+	Key       string `gorm:"size:100;not null;uniqueIndex"`
+	Value     string `gorm:"type:text;not null;default:''"`
+	UpdatedBy *uint
+	UpdatedAt time.Time
+}
+
+func (settingTableV1) TableName() string { return "settings" }
+
+func MigrateSettingsTable(db *gorm.DB) error {
+	logs.SLog.Info("Setting tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&settingTableV1{}); err != nil {
+		return errors.New("Setting tablosu migrate edilemedi: " + err.Error())
+	}
+
+	logs.SLog.Info("Setting tablosu migrate işlemi tamamlandı.")
+	return nil
+}
+
+func DropSettingsTable(db *gorm.DB) error {
+	logs.SLog.Info("Setting tablosu kaldırılıyor...")
+	if err := db.Migrator().DropTable(&settingTableV1{}); err != nil {
+		return errors.New("Setting tablosu kaldırılamadı: " + err.Error())
+	}
+
+	logs.SLog.Info("Setting tablosu kaldırıldı.")
+	return nil
+}
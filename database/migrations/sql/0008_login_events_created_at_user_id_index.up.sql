@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_login_events_created_at_user_id ON login_events (created_at, user_id);
@@ -0,0 +1,211 @@
+package migrations
+
+import (
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const sqlSourceDir = "database/migrations/sql"
+
+//go:embed sql/*.sql
+var sqlFS embed.FS
+
+var (
+	sqlFilePattern  = regexp.MustCompile(`^(\d+_[a-z0-9_]+)\.(up|down)\.sql$`)
+	sqlNamePattern  = regexp.MustCompile(`[^a-z0-9]+`)
+	idNumberPattern = regexp.MustCompile(`^(\d+)_`)
+)
+
+func Load() ([]Migration, error) {
+	sqlMigrations, err := loadSQLMigrations(sqlFS)
+	if err != nil {
+		return nil, err
+	}
+
+	all := append(All(), sqlMigrations...)
+	sort.SliceStable(all, func(i, j int) bool { return all[i].ID < all[j].ID })
+	ids := make([]string, 0, len(all))
+	for _, migration := range all {
+		ids = append(ids, migration.ID)
+	}
+	if err := checkIDNumbers(ids); err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+func checkIDNumbers(ids []string) error {
+	seen := make(map[string]string, len(ids))
+	for _, id := range ids {
+		match := idNumberPattern.FindStringSubmatch(id)
+		if match == nil {
+			return fmt.Errorf("migrasyon kimliği numara ile başlamalıdır: %s", id)
+		}
+		number := strings.TrimLeft(match[1], "0")
+		if other, ok := seen[number]; ok {
+			return fmt.Errorf("aynı numaraya sahip birden fazla migrasyon var: %s, %s", other, id)
+		}
+		seen[number] = id
+	}
+	return nil
+}
+
+func SQLDir() (string, error) {
+	if _, file, _, ok := runtime.Caller(0); ok {
+		dir := filepath.Join(filepath.Dir(file), "sql")
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			return dir, nil
+		}
+	}
+
+	var roots []string
+	if executable, err := os.Executable(); err == nil {
+		roots = append(roots, filepath.Dir(executable))
+	}
+	if wd, err := os.Getwd(); err == nil {
+		roots = append(roots, wd)
+	}
+	for _, root := range roots {
+		for dir := root; ; dir = filepath.Dir(dir) {
+			candidate := filepath.Join(dir, filepath.FromSlash(sqlSourceDir))
+			if info, err := os.Stat(candidate); err == nil && info.IsDir() {
+				return candidate, nil
+			}
+			if filepath.Dir(dir) == dir {
+				break
+			}
+		}
+	}
+	return "", fmt.Errorf("%s klasörü bulunamadı, komutu proje klasörü içinden çalıştırın", sqlSourceDir)
+}
+
+func NewSQLMigration(dir, name string) (string, string, error) {
+	slug := strings.Trim(sqlNamePattern.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if slug == "" {
+		return "", "", fmt.Errorf("geçersiz migrasyon adı: %q", name)
+	}
+
+	id := time.Now().UTC().Format("20060102150405") + "_" + slug
+
+	all, err := Load()
+	if err != nil {
+		return "", "", err
+	}
+	known := map[string]bool{id: true}
+	for _, migration := range all {
+		known[migration.ID] = true
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return "", "", err
+	}
+	for _, entry := range entries {
+		if match := sqlFilePattern.FindStringSubmatch(entry.Name()); match != nil {
+			known[match[1]] = true
+		}
+	}
+	ids := make([]string, 0, len(known))
+	for existing := range known {
+		ids = append(ids, existing)
+	}
+	sort.Strings(ids)
+	if err := checkIDNumbers(ids); err != nil {
+		return "", "", err
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", "", err
+	}
+	upPath := filepath.Join(dir, id+".up.sql")
+	downPath := filepath.Join(dir, id+".down.sql")
+	files := map[string]string{
+		upPath:   "-- " + id + ": uygulanacak SQL komutları\n",
+		downPath: "-- " + id + ": geri alma SQL komutları\n",
+	}
+	for path, content := range files {
+		if _, err := os.Stat(path); err == nil {
+			return "", "", fmt.Errorf("dosya zaten mevcut: %s", path)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			return "", "", err
+		}
+	}
+	return upPath, downPath, nil
+}
+
+func loadSQLMigrations(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, "sql")
+	if err != nil {
+		return nil, fmt.Errorf("SQL migrasyonları okunamadı: %w", err)
+	}
+
+	type sqlPair struct{ up, down *string }
+	pairs := make(map[string]*sqlPair)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		match := sqlFilePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("SQL migrasyon dosya adı NNNN_ad.up.sql / NNNN_ad.down.sql biçiminde olmalıdır: %s", entry.Name())
+		}
+		content, err := fs.ReadFile(fsys, "sql/"+entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("SQL migrasyonu okunamadı (%s): %w", entry.Name(), err)
+		}
+
+		text := string(content)
+		pair := pairs[match[1]]
+		if pair == nil {
+			pair = &sqlPair{}
+			pairs[match[1]] = pair
+		}
+		if match[2] == "up" {
+			pair.up = &text
+		} else {
+			pair.down = &text
+		}
+	}
+
+	migrations := make([]Migration, 0, len(pairs))
+	for id, pair := range pairs {
+		if pair.up == nil {
+			return nil, fmt.Errorf("%s için .up.sql dosyası bulunamadı", id)
+		}
+		migration := Migration{ID: id, Up: execSQL(*pair.up), Checksum: checksum(*pair.up, pair.down)}
+		if pair.down != nil {
+			migration.Down = execSQL(*pair.down)
+		}
+		migrations = append(migrations, migration)
+	}
+	return migrations, nil
+}
+
+func execSQL(statements string) func(tx *gorm.DB) error {
+	return func(tx *gorm.DB) error {
+		_, err := tx.Statement.ConnPool.ExecContext(tx.Statement.Context, statements)
+		return err
+	}
+}
+
+func checksum(up string, down *string) string {
+	hash := sha256.New()
+	hash.Write([]byte(up))
+	if down != nil {
+		hash.Write([]byte{0})
+		hash.Write([]byte(*down))
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,30 @@
+package migrations
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+func TestNewSQLMigrationUsesTimestamp(t *testing.T) {
+	dir := t.TempDir()
+	upPath, downPath, err := NewSQLMigration(dir, "Add Products SKU")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	name := regexp.MustCompile(`^\d{14}_add_products_sku\.(up|down)\.sql$`)
+	for _, path := range []string{upPath, downPath} {
+		if filepath.Dir(path) != dir || !name.MatchString(filepath.Base(path)) {
+			t.Fatalf("beklenmeyen dosya adı: %s", path)
+		}
+		if _, err := os.Stat(path); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, _, err := NewSQLMigration(dir, "!!!"); err == nil {
+		t.Fatal("geçersiz ad reddedilmeli")
+	}
+}
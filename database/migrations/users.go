@@ -2,39 +2,139 @@ package migrations
 
 import (
 	"errors"
-	"zatrano/models"
+	"time"
 	"zatrano/pkg/logs"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
 )
 
-func MigrateUsersTable(db *gorm.DB) error {
-	logs.SLog.Info("User tablosu için enum tipi kontrol ediliyor...")
+type userTypeColumn string
 
-	dropEnumQuery := `DROP TYPE IF EXISTS user_type;`
-	rawDB, err := db.DB()
-	if err != nil {
-		return errors.New("DB instance alınamadı: " + err.Error())
+func (userTypeColumn) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	if db.Dialector.Name() == "postgres" {
+		return "user_type"
 	}
+	return "varchar(10)"
+}
 
-	_, err = rawDB.Exec(dropEnumQuery)
-	if err != nil {
-		return errors.New("user_type enum silinemedi: " + err.Error())
-	}
-	logs.SLog.Info("user_type enum başarıyla silindi.")
+type userTableV1 struct {
+	ID        uint `gorm:"primarykey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+	CreatedBy uint
+	UpdatedBy uint
+	DeletedBy *uint `gorm:"column:deleted_by"`
+	Version   uint  `gorm:"not null;default:1"`
 
-	createEnum := `CREATE TYPE user_type AS ENUM ('dashboard', 'panel');`
-	_, err = rawDB.Exec(createEnum)
-	if err != nil {
-		return errors.New("user_type enum oluşturulamadı: " + err.Error())
+	Name      string         `gorm:"size:100;not null;index"`
+	Account   string         `gorm:"size:100;unique;not null"`
+	Password  string         `gorm:"size:255;not null"`
+	Status    bool           `gorm:"default:true;index"`
+	Type      userTypeColumn `gorm:"not null;default:'panel';index"`
+	Protected bool           `gorm:"not null;default:false"`
+	Email     string         `gorm:"size:150"`
+	Phone     string         `gorm:"size:30"`
+
+	OrganizationID uint                 `gorm:"index"`
+	Organization   *organizationTableV1 `gorm:"constraint:OnUpdate:CASCADE,OnDelete:RESTRICT"`
+	SuperAdmin     bool                 `gorm:"not null;default:false"`
+
+	ManagerID     *uint        `gorm:"index"`
+	Manager       *userTableV1 `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+	OwnAgentsOnly bool         `gorm:"not null;default:false"`
+}
+
+func (userTableV1) TableName() string { return "users" }
+
+type userMustChangePasswordColumn struct {
+	MustChangePassword bool `gorm:"not null;default:false"`
+}
+
+func (userMustChangePasswordColumn) TableName() string { return "users" }
+
+func MigrateUsersTable(db *gorm.DB) error {
+	if err := ensureUserTypeEnum(db); err != nil {
+		return err
 	}
-	logs.SLog.Info("user_type enum başarıyla oluşturuldu.")
 
 	logs.SLog.Info("User tablosu migrate ediliyor...")
-	if err := db.AutoMigrate(&models.User{}); err != nil {
+	if err := db.AutoMigrate(&userTableV1{}); err != nil {
 		return errors.New("User tablosu migrate edilemedi: " + err.Error())
 	}
 
+	statsIndexes := []string{
+		"CREATE INDEX IF NOT EXISTS idx_users_created_at ON users (created_at)",
+		"CREATE INDEX IF NOT EXISTS idx_users_updated_at ON users (updated_at)",
+	}
+	for _, indexQuery := range statsIndexes {
+		if err := db.Exec(indexQuery).Error; err != nil {
+			return errors.New("User tablosu indeksleri oluşturulamadı: " + err.Error())
+		}
+	}
+
 	logs.SLog.Info("User tablosu migrate işlemi tamamlandı.")
 	return nil
 }
+
+func DropUsersTable(db *gorm.DB) error {
+	logs.SLog.Info("User tablosu kaldırılıyor...")
+	if err := db.Migrator().DropTable(&userTableV1{}); err != nil {
+		return errors.New("User tablosu kaldırılamadı: " + err.Error())
+	}
+
+	if db.Dialector.Name() == "postgres" {
+		if err := db.Exec("DROP TYPE IF EXISTS user_type").Error; err != nil {
+			return errors.New("user_type enum silinemedi: " + err.Error())
+		}
+	}
+
+	logs.SLog.Info("User tablosu kaldırıldı.")
+	return nil
+}
+
+func AddUsersMustChangePassword(db *gorm.DB) error {
+	if db.Migrator().HasColumn(&userMustChangePasswordColumn{}, "MustChangePassword") {
+		return nil
+	}
+
+	logs.SLog.Info("users.must_change_password kolonu ekleniyor...")
+	if err := db.Migrator().AddColumn(&userMustChangePasswordColumn{}, "MustChangePassword"); err != nil {
+		return errors.New("users.must_change_password kolonu eklenemedi: " + err.Error())
+	}
+	return nil
+}
+
+func DropUsersMustChangePassword(db *gorm.DB) error {
+	if !db.Migrator().HasColumn(&userMustChangePasswordColumn{}, "MustChangePassword") {
+		return nil
+	}
+
+	logs.SLog.Info("users.must_change_password kolonu kaldırılıyor...")
+	if err := db.Migrator().DropColumn(&userMustChangePasswordColumn{}, "MustChangePassword"); err != nil {
+		return errors.New("users.must_change_password kolonu kaldırılamadı: " + err.Error())
+	}
+	return nil
+}
+
+func ensureUserTypeEnum(db *gorm.DB) error {
+	if db.Dialector.Name() != "postgres" {
+		return nil
+	}
+
+	logs.SLog.Info("User tablosu için enum tipi kontrol ediliyor...")
+	var exists bool
+	if err := db.Raw("SELECT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'user_type')").Scan(&exists).Error; err != nil {
+		return errors.New("user_type enum kontrol edilemedi: " + err.Error())
+	}
+	if exists {
+		return nil
+	}
+
+	if err := db.Exec("CREATE TYPE user_type AS ENUM ('dashboard', 'panel')").Error; err != nil {
+		return errors.New("user_type enum oluşturulamadı: " + err.Error())
+	}
+	logs.SLog.Info("user_type enum başarıyla oluşturuldu.")
+	return nil
+}
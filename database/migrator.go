@@ -0,0 +1,312 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"zatrano/database/migrations"
+	"zatrano/pkg/logs"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const migrationLockKey int64 = 7240501
+
+var (
+	ErrUnknownMigration  = errors.New("migrasyon kayıtlarda bulunamadı")
+	ErrModifiedMigration = errors.New("uygulanmış migrasyon dosyası sonradan değiştirilmiş")
+)
+
+type SchemaMigration struct {
+	ID        string    `gorm:"primaryKey;size:255"`
+	Checksum  string    `gorm:"size:64"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+func (SchemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+type MigrationStatus struct {
+	ID        string
+	Applied   bool
+	AppliedAt *time.Time
+	Missing   bool
+	Modified  bool
+	SQL       bool
+}
+
+type Migrator struct {
+	db         *gorm.DB
+	migrations []migrations.Migration
+	loadErr    error
+}
+
+func NewMigrator(db *gorm.DB) *Migrator {
+	loaded, err := migrations.Load()
+	return &Migrator{db: db, migrations: loaded, loadErr: err}
+}
+
+func (m *Migrator) Status() ([]MigrationStatus, error) {
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+
+	var statuses []MigrationStatus
+	err := m.withLock(func(conn *gorm.DB) error {
+		applied, err := m.applied(conn)
+		if err != nil {
+			return err
+		}
+
+		known := make(map[string]bool, len(m.migrations))
+		for _, migration := range m.migrations {
+			known[migration.ID] = true
+			status := MigrationStatus{ID: migration.ID, SQL: migration.Checksum != ""}
+			if record, ok := applied[migration.ID]; ok {
+				appliedAt := record.AppliedAt
+				status.Applied = true
+				status.AppliedAt = &appliedAt
+				status.Modified = isModified(migration, record)
+			}
+			statuses = append(statuses, status)
+		}
+		for id, record := range applied {
+			if known[id] {
+				continue
+			}
+			appliedAt := record.AppliedAt
+			statuses = append(statuses, MigrationStatus{ID: id, Applied: true, AppliedAt: &appliedAt, Missing: true})
+		}
+		sort.Slice(statuses, func(i, j int) bool { return statuses[i].ID < statuses[j].ID })
+		return nil
+	})
+	return statuses, err
+}
+
+func (m *Migrator) Up() (int, error) {
+	return m.To("")
+}
+
+func (m *Migrator) Down(steps int) (int, error) {
+	if steps <= 0 {
+		return 0, fmt.Errorf("geri alınacak migrasyon sayısı pozitif olmalıdır: %d", steps)
+	}
+	if err := m.validate(); err != nil {
+		return 0, err
+	}
+
+	count := 0
+	err := m.withLock(func(conn *gorm.DB) error {
+		applied, err := m.verifiedApplied(conn)
+		if err != nil {
+			return err
+		}
+
+		ids := make([]string, 0, len(applied))
+		for id := range applied {
+			ids = append(ids, id)
+		}
+		sort.Sort(sort.Reverse(sort.StringSlice(ids)))
+		if steps < len(ids) {
+			ids = ids[:steps]
+		}
+
+		for _, id := range ids {
+			if err := m.rollback(conn, id); err != nil {
+				return err
+			}
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+func (m *Migrator) To(target string) (int, error) {
+	if err := m.validate(); err != nil {
+		return 0, err
+	}
+	if target != "" && m.find(target) == nil {
+		return 0, fmt.Errorf("%w: %s", ErrUnknownMigration, target)
+	}
+
+	count := 0
+	err := m.withLock(func(conn *gorm.DB) error {
+		applied, err := m.verifiedApplied(conn)
+		if err != nil {
+			return err
+		}
+
+		if target != "" {
+			var rollbacks []string
+			for id := range applied {
+				if id > target {
+					rollbacks = append(rollbacks, id)
+				}
+			}
+			sort.Sort(sort.Reverse(sort.StringSlice(rollbacks)))
+			for _, id := range rollbacks {
+				if err := m.rollback(conn, id); err != nil {
+					return err
+				}
+				count++
+			}
+		}
+
+		for _, migration := range m.migrations {
+			if target != "" && migration.ID > target {
+				break
+			}
+			if _, ok := applied[migration.ID]; ok {
+				continue
+			}
+			if err := m.apply(conn, migration); err != nil {
+				return err
+			}
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+func (m *Migrator) apply(conn *gorm.DB, migration migrations.Migration) error {
+	logs.SLog.Infof(" -> %s uygulanıyor...", migration.ID)
+	err := conn.Transaction(func(tx *gorm.DB) error {
+		if err := migration.Up(tx); err != nil {
+			return err
+		}
+		return tx.Create(&SchemaMigration{ID: migration.ID, Checksum: migration.Checksum, AppliedAt: time.Now()}).Error
+	})
+	if err != nil {
+		logs.Log.Error("Migrasyon uygulanamadı", zap.String("migration", migration.ID), zap.Error(err))
+		return fmt.Errorf("%s uygulanamadı: %w", migration.ID, err)
+	}
+	logs.SLog.Infof(" -> %s uygulandı.", migration.ID)
+	return nil
+}
+
+func (m *Migrator) rollback(conn *gorm.DB, id string) error {
+	migration := m.find(id)
+	if migration == nil {
+		return fmt.Errorf("%w, geri alınamıyor: %s", ErrUnknownMigration, id)
+	}
+	if migration.Down == nil {
+		return fmt.Errorf("%s migrasyonu geri alınamaz", id)
+	}
+
+	logs.SLog.Infof(" -> %s geri alınıyor...", id)
+	err := conn.Transaction(func(tx *gorm.DB) error {
+		if err := migration.Down(tx); err != nil {
+			return err
+		}
+		return tx.Delete(&SchemaMigration{ID: id}).Error
+	})
+	if err != nil {
+		logs.Log.Error("Migrasyon geri alınamadı", zap.String("migration", id), zap.Error(err))
+		return fmt.Errorf("%s geri alınamadı: %w", id, err)
+	}
+	logs.SLog.Infof(" -> %s geri alındı.", id)
+	return nil
+}
+
+func (m *Migrator) applied(conn *gorm.DB) (map[string]SchemaMigration, error) {
+	if err := conn.AutoMigrate(&SchemaMigration{}); err != nil {
+		return nil, fmt.Errorf("schema_migrations tablosu oluşturulamadı: %w", err)
+	}
+
+	var records []SchemaMigration
+	if err := conn.Order("id").Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("uygulanmış migrasyonlar okunamadı: %w", err)
+	}
+
+	applied := make(map[string]SchemaMigration, len(records))
+	for _, record := range records {
+		applied[record.ID] = record
+	}
+	return applied, nil
+}
+
+func (m *Migrator) verifiedApplied(conn *gorm.DB) (map[string]SchemaMigration, error) {
+	applied, err := m.applied(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	var modified []string
+	for _, migration := range m.migrations {
+		record, ok := applied[migration.ID]
+		if !ok || migration.Checksum == "" {
+			continue
+		}
+		if record.Checksum == "" {
+			if err := conn.Model(&SchemaMigration{}).Where("id = ?", migration.ID).Update("checksum", migration.Checksum).Error; err != nil {
+				return nil, fmt.Errorf("%s için checksum kaydedilemedi: %w", migration.ID, err)
+			}
+			record.Checksum = migration.Checksum
+			applied[migration.ID] = record
+			continue
+		}
+		if isModified(migration, record) {
+			modified = append(modified, migration.ID)
+		}
+	}
+	if len(modified) > 0 {
+		logs.Log.Error("Uygulanmış migrasyonların checksum değeri değişmiş", zap.Strings("migrations", modified))
+		return nil, fmt.Errorf("%w: %s", ErrModifiedMigration, strings.Join(modified, ", "))
+	}
+	return applied, nil
+}
+
+func isModified(migration migrations.Migration, record SchemaMigration) bool {
+	return migration.Checksum != "" && record.Checksum != "" && migration.Checksum != record.Checksum
+}
+
+func (m *Migrator) withLock(fn func(conn *gorm.DB) error) error {
+	return m.db.Connection(func(conn *gorm.DB) error {
+		conn = conn.Session(&gorm.Session{})
+		if conn.Dialector.Name() != "postgres" {
+			return fn(conn)
+		}
+
+		logs.SLog.Info("Migrasyon kilidi bekleniyor...")
+		if err := conn.Exec("SELECT pg_advisory_lock(?)", migrationLockKey).Error; err != nil {
+			return fmt.Errorf("migrasyon kilidi alınamadı: %w", err)
+		}
+		defer func() {
+			if err := conn.Exec("SELECT pg_advisory_unlock(?)", migrationLockKey).Error; err != nil {
+				logs.Log.Warn("Migrasyon kilidi bırakılamadı", zap.Error(err))
+			}
+		}()
+		return fn(conn)
+	})
+}
+
+func (m *Migrator) find(id string) *migrations.Migration {
+	for i := range m.migrations {
+		if m.migrations[i].ID == id {
+			return &m.migrations[i]
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) validate() error {
+	if m.loadErr != nil {
+		return m.loadErr
+	}
+	for i, migration := range m.migrations {
+		if migration.ID == "" || migration.Up == nil {
+			return fmt.Errorf("%d. sıradaki migrasyonun kimliği veya up adımı eksik", i+1)
+		}
+		if i > 0 && m.migrations[i-1].ID >= migration.ID {
+			return fmt.Errorf("migrasyon kimlikleri artan sırada ve benzersiz olmalıdır: %s, %s", m.migrations[i-1].ID, migration.ID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package database_test
+
+import (
+	"testing"
+	"time"
+
+	"zatrano/configs"
+	"zatrano/database"
+)
+
+func TestRenamedBackfillReplacesOldRecord(t *testing.T) {
+	db := configs.GetDB()
+	if err := db.Delete(&database.SchemaMigration{ID: "0012_backfill_organization_ids"}).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Create(&database.SchemaMigration{ID: "0002_organizations_backfill", AppliedAt: time.Now()}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	applied, err := database.NewMigrator(db).Up()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if applied != 1 {
+		t.Fatalf("yalnızca yeniden numaralanan migrasyon uygulanmalıydı, uygulanan: %d", applied)
+	}
+
+	statuses, err := database.NewMigrator(db).Status()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, status := range statuses {
+		if status.Missing || !status.Applied {
+			t.Errorf("beklenmeyen migrasyon durumu: %+v", status)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package database
+
+import (
+	"context"
+	"errors"
+
+	"zatrano/models"
+	"zatrano/pkg/audit"
+	"zatrano/pkg/logs"
+	"zatrano/pkg/tenant"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func SetUserProtection(db *gorm.DB, account string, protected bool) error {
+	if account == "" {
+		return errors.New("hesap adı boş olamaz")
+	}
+
+	found, err := setUserFlag(db, "protected", protected, "account = ?", account)
+	if err != nil {
+		logs.Log.Error("Kullanıcı koruma durumu güncellenemedi", zap.String("account", account), zap.Error(err))
+		return err
+	}
+	if !found {
+		return errors.New("kullanıcı bulunamadı: " + account)
+	}
+
+	logs.Log.Info("Kullanıcı koruma durumu güncellendi", zap.String("account", account), zap.Bool("protected", protected))
+	return nil
+}
+
+func SetUserSuperAdmin(db *gorm.DB, account string, superAdmin bool) error {
+	if account == "" {
+		return errors.New("hesap adı boş olamaz")
+	}
+
+	found, err := setUserFlag(db, "super_admin", superAdmin, "account = ? AND type = ?", account, models.Dashboard)
+	if err != nil {
+		logs.Log.Error("Kullanıcı süper yönetici durumu güncellenemedi", zap.String("account", account), zap.Error(err))
+		return err
+	}
+	if !found {
+		return errors.New("yönetici kullanıcı bulunamadı: " + account)
+	}
+
+	logs.Log.Info("Kullanıcı süper yönetici durumu güncellendi", zap.String("account", account), zap.Bool("super_admin", superAdmin))
+	return nil
+}
+
+func setUserFlag(db *gorm.DB, column string, value bool, query string, args ...interface{}) (bool, error) {
+	found := false
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var user struct {
+			ID             uint
+			OrganizationID uint
+			Current        bool
+		}
+		err := tx.Model(&models.User{}).Select("id", "organization_id", column+" AS current").Where(query, args...).Take(&user).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		found = true
+		if user.Current == value {
+			return nil
+		}
+
+		if err := tx.Model(&models.User{}).Where("id = ?", user.ID).UpdateColumn(column, value).Error; err != nil {
+			return err
+		}
+		ctx := tenant.WithOrganization(context.Background(), user.OrganizationID)
+		return audit.Record(ctx, tx, audit.Entry{
+			Action:     audit.ActionUpdate,
+			EntityType: "users",
+			EntityID:   user.ID,
+			Changes:    models.AuditChanges{column: {Old: user.Current, New: value}},
+		})
+	})
+	return found, err
+}
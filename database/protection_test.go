@@ -0,0 +1,78 @@
+package database_test
+
+import (
+	"os"
+	"testing"
+
+	"zatrano/configs"
+	"zatrano/database"
+	"zatrano/database/testdb"
+	"zatrano/models"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(testdb.Run(m))
+}
+
+func TestUserFlagsAreAudited(t *testing.T) {
+	ctx := testdb.Organization(t)
+	admin := testdb.User(t, ctx, models.User{Account: "admin@test", Type: models.Dashboard})
+	agent := testdb.User(t, ctx, models.User{Account: "agent@test"})
+	db := configs.GetDB()
+
+	tests := []struct {
+		name    string
+		user    *models.User
+		column  string
+		apply   func() error
+		want    bool
+		audited bool
+	}{
+		{name: "protect", user: admin, column: "protected", apply: func() error { return database.SetUserProtection(db, admin.Account, true) }, want: true, audited: true},
+		{name: "protect again", user: admin, column: "protected", apply: func() error { return database.SetUserProtection(db, admin.Account, true) }, want: true},
+		{name: "unprotect", user: admin, column: "protected", apply: func() error { return database.SetUserProtection(db, admin.Account, false) }, want: false, audited: true},
+		{name: "grant super admin", user: admin, column: "super_admin", apply: func() error { return database.SetUserSuperAdmin(db, admin.Account, true) }, want: true, audited: true},
+		{name: "revoke super admin", user: admin, column: "super_admin", apply: func() error { return database.SetUserSuperAdmin(db, admin.Account, false) }, want: false, audited: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var before int64
+			db.Model(&models.AuditLog{}).Where("entity_id = ?", tt.user.ID).Count(&before)
+			if err := tt.apply(); err != nil {
+				t.Fatal(err)
+			}
+
+			var entries []models.AuditLog
+			if err := db.Where("entity_type = ? AND entity_id = ?", "users", tt.user.ID).Order("id").Find(&entries).Error; err != nil {
+				t.Fatal(err)
+			}
+			if !tt.audited {
+				if int64(len(entries)) != before {
+					t.Fatalf("değişmeyen değer için denetim kaydı yazılmamalı")
+				}
+				return
+			}
+			if int64(len(entries)) != before+1 {
+				t.Fatalf("1 yeni denetim kaydı bekleniyordu, önce %d, sonra %d", before, len(entries))
+			}
+			entry := entries[len(entries)-1]
+			if entry.ActorID != nil {
+				t.Errorf("komut satırı değişikliği sistem tarafından yapılmış görünmeli: %v", *entry.ActorID)
+			}
+			if entry.OrganizationID == nil || *entry.OrganizationID != tt.user.OrganizationID {
+				t.Errorf("denetim kaydı kullanıcının organizasyonuna yazılmalı: %v", entry.OrganizationID)
+			}
+			if change := entry.Changes[tt.column]; change.Old != !tt.want || change.New != tt.want {
+				t.Errorf("%s değişikliği hatalı: %+v", tt.column, entry.Changes)
+			}
+		})
+	}
+
+	if err := database.SetUserSuperAdmin(db, agent.Account, true); err == nil {
+		t.Fatal("panel kullanıcısı süper yönetici yapılamamalı")
+	}
+	if err := database.SetUserProtection(db, "yok@test", true); err == nil {
+		t.Fatal("olmayan kullanıcı için hata dönmeli")
+	}
+}
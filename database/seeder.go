@@ -0,0 +1,179 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"zatrano/database/seeders"
+	"zatrano/pkg/logs"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrUnknownSeeder    = errors.New("seeder kayıtlarda bulunamadı")
+	ErrSeederNotAllowed = errors.New("seeder bu ortamda çalıştırılamaz")
+)
+
+type SeederRun struct {
+	Name  string    `gorm:"primaryKey;size:100"`
+	Env   string    `gorm:"size:10;not null"`
+	RunAt time.Time `gorm:"not null"`
+}
+
+func (SeederRun) TableName() string {
+	return "seeder_runs"
+}
+
+type SeederStatus struct {
+	seeders.Seeder
+	Allowed bool
+	RunAt   *time.Time
+}
+
+type SeederRunner struct {
+	db      *gorm.DB
+	seeders []seeders.Seeder
+	env     string
+}
+
+func NewSeederRunner(db *gorm.DB) *SeederRunner {
+	return &SeederRunner{db: db, seeders: seeders.All(), env: seeders.CurrentEnv()}
+}
+
+func (r *SeederRunner) Env() string {
+	return r.env
+}
+
+func (r *SeederRunner) Run(names ...string) (int, error) {
+	plan, err := r.plan(names)
+	if err != nil {
+		return 0, err
+	}
+
+	executed, err := r.executed()
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, seeder := range plan {
+		if record, ok := executed[seeder.Name]; ok && seeder.Once {
+			logs.SLog.Infof(" -> %s daha önce çalıştırıldı (%s), atlanıyor.", seeder.Name, record.RunAt.Format("2006-01-02 15:04:05"))
+			continue
+		}
+		if err := r.execute(seeder); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
+
+func (r *SeederRunner) Status() ([]SeederStatus, error) {
+	executed, err := r.executed()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]SeederStatus, 0, len(r.seeders))
+	for _, seeder := range r.seeders {
+		status := SeederStatus{Seeder: seeder, Allowed: seeder.AllowedIn(r.env)}
+		if record, ok := executed[seeder.Name]; ok {
+			runAt := record.RunAt
+			status.RunAt = &runAt
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+func (r *SeederRunner) executed() (map[string]SeederRun, error) {
+	if err := r.db.AutoMigrate(&SeederRun{}); err != nil {
+		return nil, fmt.Errorf("seeder_runs tablosu oluşturulamadı: %w", err)
+	}
+
+	var records []SeederRun
+	if err := r.db.Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("çalıştırılmış seeder'lar okunamadı: %w", err)
+	}
+
+	executed := make(map[string]SeederRun, len(records))
+	for _, record := range records {
+		executed[record.Name] = record
+	}
+	return executed, nil
+}
+
+func (r *SeederRunner) execute(seeder seeders.Seeder) error {
+	logs.SLog.Infof("Seeder çalıştırılıyor: %s", seeder.Name)
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := seeder.Run(tx); err != nil {
+			return err
+		}
+		return tx.Save(&SeederRun{Name: seeder.Name, Env: r.env, RunAt: time.Now()}).Error
+	})
+	if err != nil {
+		logs.Log.Error("Seeder başarısız oldu, geri alındı", zap.String("seeder", seeder.Name), zap.Error(err))
+		return fmt.Errorf("%s seeder'ı çalıştırılamadı: %w", seeder.Name, err)
+	}
+	logs.SLog.Infof(" -> %s tamamlandı.", seeder.Name)
+	return nil
+}
+
+func (r *SeederRunner) plan(names []string) ([]seeders.Seeder, error) {
+	byName := make(map[string]seeders.Seeder, len(r.seeders))
+	for _, seeder := range r.seeders {
+		if _, exists := byName[seeder.Name]; exists {
+			return nil, fmt.Errorf("aynı ada sahip birden fazla seeder var: %s", seeder.Name)
+		}
+		byName[seeder.Name] = seeder
+	}
+
+	if len(names) == 0 {
+		for _, seeder := range r.seeders {
+			if seeder.AllowedIn(r.env) {
+				names = append(names, seeder.Name)
+			}
+		}
+	}
+
+	var plan []seeders.Seeder
+	state := make(map[string]int, len(byName))
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		seeder, ok := byName[name]
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrUnknownSeeder, name)
+		}
+		switch state[name] {
+		case 1:
+			return fmt.Errorf("seeder bağımlılıklarında döngü var: %s", strings.Join(append(path, name), " -> "))
+		case 2:
+			return nil
+		}
+		if !seeder.AllowedIn(r.env) {
+			return fmt.Errorf("%w: %s (ortam: %s, izin verilen: %s)", ErrSeederNotAllowed, name, r.env, strings.Join(seeder.Envs, ","))
+		}
+
+		state[name] = 1
+		for _, dependency := range seeder.DependsOn {
+			if err := visit(dependency, append(path, name)); err != nil {
+				return err
+			}
+		}
+		state[name] = 2
+		plan = append(plan, seeder)
+		return nil
+	}
+
+	for _, name := range names {
+		if err := visit(strings.TrimSpace(name), nil); err != nil {
+			return nil, err
+		}
+	}
+	return plan, nil
+}
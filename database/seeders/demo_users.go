@@ -0,0 +1,89 @@
+package seeders
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"zatrano/database/migrations"
+	"zatrano/models"
+	"zatrano/pkg/logs"
+
+	"gorm.io/gorm"
+)
+
+const demoUserPassword = "demo1234"
+
+type demoUserFixture struct {
+	Name          string `json:"name" yaml:"name"`
+	Account       string `json:"account" yaml:"account"`
+	Type          string `json:"type" yaml:"type"`
+	Password      string `json:"password" yaml:"password"`
+	Email         string `json:"email" yaml:"email"`
+	Phone         string `json:"phone" yaml:"phone"`
+	Manager       string `json:"manager" yaml:"manager"`
+	OwnAgentsOnly bool   `json:"own_agents_only" yaml:"own_agents_only"`
+}
+
+func SeedDemoUsers(db *gorm.DB) error {
+	var fixtures []demoUserFixture
+	if err := LoadFixture("demo_users.json", &fixtures); err != nil {
+		return err
+	}
+
+	var systemUser models.User
+	if err := db.Where("account = ?", GetSystemUserConfig().Account).First(&systemUser).Error; err != nil {
+		return errors.New("demo kullanıcıları için sistem kullanıcısı bulunamadı: " + err.Error())
+	}
+	organizationID, err := migrations.EnsureDefaultOrganization(db)
+	if err != nil {
+		return err
+	}
+	db = db.WithContext(context.WithValue(context.Background(), "user_id", systemUser.ID))
+
+	accounts := make(map[string]uint, len(fixtures))
+	for _, fixture := range fixtures {
+		var existing models.User
+		err := db.Where("account = ?", fixture.Account).Limit(1).Find(&existing).Error
+		if err != nil {
+			return fmt.Errorf("%s kontrol edilemedi: %w", fixture.Account, err)
+		}
+		if existing.ID != 0 {
+			logs.SLog.Infof(" -> %s zaten mevcut, atlanıyor.", fixture.Account)
+			accounts[fixture.Account] = existing.ID
+			continue
+		}
+
+		user := models.User{
+			Name:           fixture.Name,
+			Account:        fixture.Account,
+			Type:           models.UserType(fixture.Type),
+			Status:         true,
+			Email:          fixture.Email,
+			Phone:          fixture.Phone,
+			OrganizationID: organizationID,
+			OwnAgentsOnly:  fixture.OwnAgentsOnly,
+		}
+		if fixture.Manager != "" {
+			managerID, ok := accounts[fixture.Manager]
+			if !ok {
+				return fmt.Errorf("%s için yönetici fixture içinde önce tanımlanmalı: %s", fixture.Account, fixture.Manager)
+			}
+			user.ManagerID = &managerID
+		}
+
+		password := fixture.Password
+		if password == "" {
+			password = demoUserPassword
+		}
+		if err := user.SetPassword(password); err != nil {
+			return err
+		}
+		if err := db.Create(&user).Error; err != nil {
+			return fmt.Errorf("%s oluşturulamadı: %w", fixture.Account, err)
+		}
+		accounts[fixture.Account] = user.ID
+		logs.SLog.Infof(" -> %s oluşturuldu.", fixture.Account)
+	}
+	return nil
+}
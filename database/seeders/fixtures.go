@@ -0,0 +1,39 @@
+package seeders
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed fixtures
+var fixtureFS embed.FS
+
+func LoadFixture(name string, out interface{}) error {
+	data, err := fixtureFS.ReadFile(path.Join("fixtures", name))
+	if err != nil {
+		return fmt.Errorf("fixture dosyası okunamadı (%s): %w", name, err)
+	}
+
+	switch strings.ToLower(path.Ext(name)) {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		err = decoder.Decode(out)
+	case ".json":
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(out)
+	default:
+		return fmt.Errorf("desteklenmeyen fixture biçimi: %s (yalnızca .yaml, .yml ve .json)", name)
+	}
+	if err != nil {
+		return fmt.Errorf("fixture dosyası çözümlenemedi (%s): %w", name, err)
+	}
+	return nil
+}
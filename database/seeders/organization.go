@@ -0,0 +1,12 @@
+package seeders
+
+import (
+	"zatrano/database/migrations"
+
+	"gorm.io/gorm"
+)
+
+func SeedDefaultOrganization(db *gorm.DB) error {
+	_, err := migrations.EnsureDefaultOrganization(db)
+	return err
+}
@@ -0,0 +1,55 @@
+package seeders
+
+import (
+	"os"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+const (
+	EnvAll  = "all"
+	EnvDev  = "dev"
+	EnvTest = "test"
+	EnvProd = "prod"
+)
+
+type Seeder struct {
+	Name      string
+	DependsOn []string
+	Envs      []string
+	Once      bool
+	Run       func(tx *gorm.DB) error
+}
+
+func All() []Seeder {
+	return []Seeder{
+		{Name: "organizations", Envs: []string{EnvAll}, Run: SeedDefaultOrganization},
+		{Name: "users", DependsOn: []string{"organizations"}, Envs: []string{EnvAll}, Run: SeedSystemUser},
+		{Name: "settings", Envs: []string{EnvDev, EnvTest}, Once: true, Run: SeedSettings},
+		{Name: "demo_users", DependsOn: []string{"users"}, Envs: []string{EnvDev, EnvTest}, Once: true, Run: SeedDemoUsers},
+	}
+}
+
+func (s Seeder) AllowedIn(env string) bool {
+	if len(s.Envs) == 0 {
+		return true
+	}
+	for _, allowed := range s.Envs {
+		if allowed == EnvAll || allowed == env {
+			return true
+		}
+	}
+	return false
+}
+
+func CurrentEnv() string {
+	switch strings.ToLower(os.Getenv("APP_ENV")) {
+	case "production", "prod":
+		return EnvProd
+	case "test", "testing":
+		return EnvTest
+	default:
+		return EnvDev
+	}
+}
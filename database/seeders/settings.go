@@ -0,0 +1,40 @@
+package seeders
+
+import (
+	"fmt"
+
+	"zatrano/models"
+	"zatrano/pkg/logs"
+	"zatrano/pkg/settings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func SeedSettings(db *gorm.DB) error {
+	var values map[string]string
+	if err := LoadFixture("settings.yaml", &values); err != nil {
+		return err
+	}
+
+	for key, value := range values {
+		definition, ok := settings.Lookup(key)
+		if !ok {
+			return fmt.Errorf("fixture içinde tanımsız ayar anahtarı: %s", key)
+		}
+		normalized, err := definition.Normalize(value)
+		if err != nil {
+			return fmt.Errorf("%s ayarı geçersiz: %w", key, err)
+		}
+
+		setting := models.Setting{Key: key, Value: normalized}
+		result := db.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "key"}}, DoNothing: true}).Create(&setting)
+		if result.Error != nil {
+			return fmt.Errorf("%s ayarı kaydedilemedi: %w", key, result.Error)
+		}
+		if result.RowsAffected == 0 {
+			logs.SLog.Infof(" -> %s ayarı zaten tanımlı, atlanıyor.", key)
+		}
+	}
+	return nil
+}
@@ -1,41 +1,53 @@
 package seeders
 
 import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"os"
+
+	"zatrano/database/migrations"
 	"zatrano/models"
+	"zatrano/pkg/env"
 	"zatrano/pkg/logs"
 
 	"go.uber.org/zap"
-	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
 
+const (
+	legacySystemPassword    = "ZATRANO"
+	minSystemPasswordLength = 6
+	generatedPasswordLength = 16
+	passwordAlphabet        = "ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz23456789"
+)
+
 func GetSystemUserConfig() models.User {
 	return models.User{
-		Name:     "ZATRANO",
-		Account:  "zatrano@zatrano",
+		Name:     env.GetEnvWithDefault("ADMIN_NAME", "ZATRANO"),
+		Account:  env.GetEnvWithDefault("ADMIN_ACCOUNT", "zatrano@zatrano"),
 		Type:     models.Dashboard,
-		Password: "ZATRANO",
+		Password: os.Getenv("ADMIN_PASSWORD"),
 	}
 }
 
 func SeedSystemUser(db *gorm.DB) error {
 	systemUserConfig := GetSystemUserConfig()
 
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(systemUserConfig.Password), bcrypt.DefaultCost)
+	organizationID, err := migrations.EnsureDefaultOrganization(db)
 	if err != nil {
-		logs.Log.Error("Sistem kullanıcısının şifresi hash'lenirken hata oluştu",
-			zap.String("account", systemUserConfig.Account),
-			zap.Error(err),
-		)
+		logs.Log.Error("Sistem kullanıcısı için organizasyon belirlenemedi", zap.Error(err))
 		return err
 	}
 
 	userToSeed := models.User{
-		Name:     systemUserConfig.Name,
-		Account:  systemUserConfig.Account,
-		Type:     systemUserConfig.Type,
-		Password: string(hashedPassword),
-		Status:   true,
+		Name:           systemUserConfig.Name,
+		Account:        systemUserConfig.Account,
+		Type:           systemUserConfig.Type,
+		Status:         true,
+		Protected:      true,
+		OrganizationID: organizationID,
+		SuperAdmin:     true,
 	}
 
 	var existingUser models.User
@@ -55,10 +67,23 @@ func SeedSystemUser(db *gorm.DB) error {
 			updateFields["status"] = true
 			needsUpdate = true
 		}
+		if !existingUser.Protected {
+			updateFields["protected"] = true
+			needsUpdate = true
+		}
+		if !existingUser.SuperAdmin {
+			updateFields["super_admin"] = true
+			needsUpdate = true
+		}
+		if !existingUser.MustChangePassword && existingUser.CheckPassword(legacySystemPassword) == nil {
+			logs.SLog.Warnf("Sistem kullanıcısı '%s' hâlâ varsayılan şifreyi kullanıyor, ilk girişte şifre değişikliği zorunlu tutulacak.", userToSeed.Account)
+			updateFields["must_change_password"] = true
+			needsUpdate = true
+		}
 
 		if needsUpdate {
 			logs.SLog.Info("Mevcut sistem kullanıcısı '%s' güncelleniyor...", userToSeed.Account)
-			err := db.Model(&existingUser).Updates(updateFields).Error
+			err := db.Model(&existingUser).UpdateColumns(updateFields).Error
 			if err != nil {
 				logs.Log.Error("Mevcut sistem kullanıcısı güncellenemedi",
 					zap.String("account", userToSeed.Account),
@@ -81,7 +106,26 @@ func SeedSystemUser(db *gorm.DB) error {
 	}
 
 	logs.SLog.Info("Sistem kullanıcısı '%s' bulunamadı. Oluşturuluyor...", userToSeed.Account)
-	err = db.Create(&userToSeed).Error
+	password, generated := systemUserConfig.Password, false
+	if password == "" {
+		if password, err = generatePassword(); err != nil {
+			logs.Log.Error("Sistem kullanıcısı için rastgele şifre üretilemedi", zap.Error(err))
+			return err
+		}
+		generated = true
+	} else if len(password) < minSystemPasswordLength {
+		return fmt.Errorf("ADMIN_PASSWORD en az %d karakter olmalıdır", minSystemPasswordLength)
+	}
+	if err := userToSeed.SetPassword(password); err != nil {
+		logs.Log.Error("Sistem kullanıcısının şifresi hash'lenirken hata oluştu",
+			zap.String("account", userToSeed.Account),
+			zap.Error(err),
+		)
+		return err
+	}
+	userToSeed.MustChangePassword = true
+	userToSeed.Version = 1
+	err = db.Session(&gorm.Session{SkipHooks: true}).Create(&userToSeed).Error
 	if err != nil {
 		logs.Log.Error("Sistem kullanıcısı oluşturulamadı",
 			zap.String("account", userToSeed.Account),
@@ -91,5 +135,32 @@ func SeedSystemUser(db *gorm.DB) error {
 	}
 
 	logs.SLog.Info("Sistem kullanıcısı '%s' başarıyla oluşturuldu.", userToSeed.Account)
+	if generated {
+		printGeneratedPassword(userToSeed.Account, password)
+	}
 	return nil
 }
+
+func generatePassword() (string, error) {
+	alphabetSize := big.NewInt(int64(len(passwordAlphabet)))
+	buf := make([]byte, generatedPasswordLength)
+	for i := range buf {
+		n, err := rand.Int(rand.Reader, alphabetSize)
+		if err != nil {
+			return "", err
+		}
+		buf[i] = passwordAlphabet[n.Int64()]
+	}
+	return string(buf), nil
+}
+
+func printGeneratedPassword(account, password string) {
+	fmt.Println()
+	fmt.Println("  ==============================================================")
+	fmt.Println("  Sistem yöneticisi oluşturuldu. Bu şifre yalnızca bir kez gösterilir.")
+	fmt.Println("  Hesap :", account)
+	fmt.Println("  Şifre :", password)
+	fmt.Println("  İlk girişte şifrenin değiştirilmesi istenecektir.")
+	fmt.Println("  ==============================================================")
+	fmt.Println()
+}
@@ -0,0 +1,89 @@
+package testdb
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"testing"
+
+	"zatrano/configs"
+	"zatrano/database"
+	"zatrano/models"
+	"zatrano/pkg/logs"
+	"zatrano/pkg/tenant"
+
+	"go.uber.org/zap"
+)
+
+const (
+	contextUserIDKey = "user_id"
+	fixtureUserID    = uint(1)
+)
+
+var organizationSeq atomic.Uint64
+
+func Run(m *testing.M) int {
+	os.Setenv("DB_DRIVER", configs.DriverSQLite)
+	os.Setenv("DB_DATABASE", ":memory:")
+	if os.Getenv("DB_LOG_LEVEL") == "" {
+		os.Setenv("DB_LOG_LEVEL", "silent")
+	}
+
+	if os.Getenv("TEST_LOG") == "" {
+		logs.Log = zap.NewNop()
+		logs.SLog = logs.Log.Sugar()
+	} else {
+		logs.InitLogger()
+	}
+
+	configs.InitDB()
+	defer configs.CloseDB()
+
+	if _, err := database.NewMigrator(configs.GetDB()).Up(); err != nil {
+		fmt.Fprintf(os.Stderr, "test veritabanı migrate edilemedi: %v\n", err)
+		return 1
+	}
+	return m.Run()
+}
+
+func Organization(t testing.TB) context.Context {
+	t.Helper()
+	organization := models.Organization{Name: fmt.Sprintf("%s #%d", t.Name(), organizationSeq.Add(1)), Status: true}
+	if err := configs.GetDB().Create(&organization).Error; err != nil {
+		t.Fatalf("organizasyon oluşturulamadı: %v", err)
+	}
+	return tenant.WithOrganization(context.Background(), organization.ID)
+}
+
+func As(ctx context.Context, userID uint) context.Context {
+	return context.WithValue(ctx, contextUserIDKey, userID)
+}
+
+func User(t testing.TB, ctx context.Context, user models.User) *models.User {
+	t.Helper()
+	if user.Name == "" {
+		user.Name = user.Account
+	}
+	if user.Type == "" {
+		user.Type = models.Panel
+	}
+	if user.Password == "" {
+		user.Password = "secret123"
+	}
+	if err := user.SetPassword(user.Password); err != nil {
+		t.Fatalf("şifre oluşturulamadı: %v", err)
+	}
+	if organizationID, ok := tenant.OrganizationID(ctx); ok {
+		user.OrganizationID = organizationID
+		user.Account = fmt.Sprintf("org%d.%s", organizationID, user.Account)
+	}
+
+	if _, ok := ctx.Value(contextUserIDKey).(uint); !ok {
+		ctx = As(ctx, fixtureUserID)
+	}
+	if err := configs.GetDB().WithContext(ctx).Create(&user).Error; err != nil {
+		t.Fatalf("kullanıcı oluşturulamadı (%s): %v", user.Account, err)
+	}
+	return &user
+}
@@ -7,23 +7,33 @@ import (
 	"zatrano/pkg/logs"
 	"zatrano/pkg/renderer"
 	"zatrano/pkg/sessions"
+	"zatrano/pkg/settings"
+	"zatrano/pkg/validation"
 	"zatrano/services"
 
 	"github.com/gofiber/fiber/v2"
 	"go.uber.org/zap"
 )
 
+type UpdatePasswordRequest struct {
+	CurrentPassword string `form:"current_password" validate:"required" label:"Mevcut Şifre"`
+	NewPassword     string `form:"new_password" validate:"required,min=6,nefield=CurrentPassword" label:"Yeni Şifre"`
+	ConfirmPassword string `form:"confirm_password" validate:"required,eqfield=NewPassword" label:"Yeni Şifre (Tekrar)"`
+}
+
 type AuthHandler struct {
 	service services.IAuthService
 }
 
 func NewAuthHandler() *AuthHandler {
+	validation.MustParse(UpdatePasswordRequest{})
 	return &AuthHandler{service: services.NewAuthService()}
 }
 
 func (h *AuthHandler) ShowLogin(c *fiber.Ctx) error {
 	mapData := fiber.Map{
-		"Title": "Giriş",
+		"Title":  settings.String(settings.LoginTitle),
+		"Notice": settings.String(settings.LoginNotice),
 	}
 	return renderer.Render(c, "auth/login", "layouts/auth", mapData, http.StatusOK)
 }
@@ -50,9 +60,9 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
 		var errMsg string
 		switch err {
 		case services.ErrInvalidCredentials:
-			errMsg = "Kullanıcı adı veya şifre hatalı."
+			errMsg = settings.String(settings.InvalidLoginMessage)
 		case services.ErrUserInactive:
-			errMsg = "Hesabınız aktif değil. Lütfen yöneticinizle iletişime geçin."
+			errMsg = settings.String(settings.InactiveUserMessage)
 		default:
 			errMsg = "Giriş işlemi sırasında bir sorun oluştu. Lütfen tekrar deneyin."
 			logs.Log.Error("Kimlik doğrulama servisinde beklenmeyen hata",
@@ -75,6 +85,8 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
 	sess.Set("user_type", string(user.Type))
 	sess.Set("user_status", user.Status)
 	sess.Set("user_name", user.Name)
+	sess.Delete("organization_id")
+	sess.SetExpiry(settings.Hours(settings.SessionExpirationHours))
 
 	if saveErr := sess.Save(); saveErr != nil {
 		logs.Log.Error("Oturum kaydedilemedi (Login)", zap.Uint("user_id", user.ID), zap.String("account", user.Account), zap.Error(saveErr))
@@ -82,6 +94,10 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
 		return c.Redirect("/auth/login", fiber.StatusSeeOther)
 	}
 
+	if err := h.service.RecordLogin(user.ID, c.IP(), c.Get(fiber.HeaderUserAgent)); err != nil {
+		logs.Log.Warn("Giriş kaydı tutulamadı, oturum açma işlemine devam ediliyor", zap.Uint("user_id", user.ID), zap.Error(err))
+	}
+
 	var redirectURL string
 	switch user.Type {
 	case models.Panel:
@@ -95,7 +111,12 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
 		return c.Redirect("/auth/login", fiber.StatusSeeOther)
 	}
 
-	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Başarıyla giriş yapıldı.")
+	if user.MustChangePassword {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Devam etmeden önce şifrenizi değiştirmeniz gerekiyor.")
+		return c.Redirect("/auth/change-password", fiber.StatusFound)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, settings.String(settings.LoginSuccessMessage))
 	return c.Redirect(redirectURL, fiber.StatusFound)
 }
 
@@ -132,7 +153,7 @@ func (h *AuthHandler) Profile(c *fiber.Ctx) error {
 		logs.SLog.Debugf("Profil: UserID session'dan alındı: %d", userID)
 	}
 
-	user, err := h.service.GetUserProfile(userID)
+	user, err := h.service.GetUserProfile(c.UserContext(), userID)
 	if err != nil {
 		var errMsg string
 		if err == services.ErrUserNotFound {
@@ -157,6 +178,15 @@ func (h *AuthHandler) Profile(c *fiber.Ctx) error {
 	return renderer.Render(c, "auth/profile", "layouts/auth", mapData, http.StatusOK)
 }
 
+func (h *AuthHandler) ShowChangePassword(c *fiber.Ctx) error {
+	mapData := fiber.Map{
+		"Title":          "Şifre Değiştir",
+		"ForcedChange":   true,
+		"PasswordAction": "/auth/change-password",
+	}
+	return renderer.Render(c, "auth/profile", "layouts/auth", mapData, http.StatusOK)
+}
+
 func (h *AuthHandler) Logout(c *fiber.Ctx) error {
 	sess, err := sessions.SessionStart(c)
 	if err != nil {
@@ -187,39 +217,43 @@ func (h *AuthHandler) UpdatePassword(c *fiber.Ctx) error {
 		return c.Redirect("/auth/login", fiber.StatusSeeOther)
 	}
 
-	var request struct {
-		CurrentPassword string `form:"current_password"`
-		NewPassword     string `form:"new_password"`
-		ConfirmPassword string `form:"confirm_password"`
+	var request UpdatePasswordRequest
+	forcedChange := c.Path() == "/auth/change-password"
+	formPage := "/auth/profile"
+	if forcedChange {
+		formPage = "/auth/change-password"
 	}
 
 	if err := c.BodyParser(&request); err != nil {
 		logs.SLog.Warnf("Parola güncelleme isteği ayrıştırılamadı: %v", err)
 		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Lütfen tüm şifre alanlarını doldurun.")
-		return c.Redirect("/auth/profile", fiber.StatusSeeOther)
+		return c.Redirect(formPage, fiber.StatusSeeOther)
 	}
 
-	if request.CurrentPassword == "" || request.NewPassword == "" || request.ConfirmPassword == "" {
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Lütfen tüm şifre alanlarını doldurun.")
-		return c.Redirect("/auth/profile", fiber.StatusSeeOther)
-	}
-	if request.NewPassword != request.ConfirmPassword {
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Yeni şifreler uyuşmuyor.")
-		return c.Redirect("/auth/profile", fiber.StatusSeeOther)
+	if errs := validation.Validate(request); errs != nil {
+		mapData := fiber.Map{
+			"Title":                    "Profilim",
+			renderer.FlashErrorKeyView: "Lütfen formdaki hatalı alanları düzeltin.",
+			renderer.FieldErrorsKey:    errs,
+		}
+		if forcedChange {
+			mapData["Title"] = "Şifre Değiştir"
+			mapData["ForcedChange"] = true
+			mapData["PasswordAction"] = "/auth/change-password"
+		}
+		return renderer.Render(c, "auth/profile", "layouts/auth", mapData, http.StatusBadRequest)
 	}
 
-	err := h.service.UpdatePassword(userID, request.CurrentPassword, request.NewPassword)
+	err := h.service.UpdatePassword(c.UserContext(), userID, request.CurrentPassword, request.NewPassword)
 	if err != nil {
 		var errMsg string
 		flashKey := flashmessages.FlashErrorKey
-		redirectTarget := "/auth/profile"
+		redirectTarget := formPage
 		logoutUser := false
 
 		switch err {
 		case services.ErrCurrentPasswordIncorrect:
 			errMsg = "Mevcut şifreniz hatalı."
-		case services.ErrPasswordTooShort, services.ErrPasswordSameAsOld:
-			errMsg = err.Error()
 		case services.ErrUserNotFound:
 			errMsg = "Kullanıcı bulunamadı, lütfen tekrar giriş yapın."
 			logoutUser = true
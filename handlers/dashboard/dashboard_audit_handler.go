@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"html/template"
+	"net/http"
+	"zatrano/pkg/domainerrors"
+	"zatrano/pkg/logs"
+	"zatrano/pkg/queryparams"
+	"zatrano/pkg/renderer"
+	"zatrano/pkg/validation"
+	"zatrano/services"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type AuditHandler struct {
+	service services.IAuditService
+}
+
+func NewAuditHandler() *AuditHandler {
+	return &AuditHandler{service: services.NewAuditService()}
+}
+
+func (h *AuditHandler) ListAuditLogs(c *fiber.Ctx) error {
+	var query services.AuditQuery
+	if err := c.QueryParser(&query); err != nil {
+		logs.Log.Warn("Denetim kayıtları: Query parametreleri parse edilemedi, filtreler yok sayılıyor.", zap.Error(err))
+		query = services.AuditQuery{}
+	}
+
+	entityTypes, err := h.service.GetEntityTypes(c.UserContext())
+	if err != nil {
+		logs.Log.Warn("Denetim kayıtları: Kayıt türleri alınamadı", zap.Error(err))
+	}
+
+	renderData := fiber.Map{
+		"Title":       "Denetim Kayıtları",
+		"Query":       query,
+		"FilterQuery": template.URL(query.Encode()),
+		"Actions":     services.AuditActions,
+		"EntityTypes": entityTypes,
+	}
+	status := http.StatusOK
+
+	page, err := h.service.List(c.UserContext(), query)
+	if err != nil {
+		if errs, ok := err.(validation.Errors); ok {
+			renderData[renderer.FlashErrorKeyView] = "Lütfen filtre alanlarını kontrol edin."
+			renderData[renderer.FieldErrorsKey] = errs
+			status = http.StatusBadRequest
+		} else {
+			renderData[renderer.FlashErrorKeyView] = domainerrors.UserMessage(err)
+			status = domainerrors.HTTPStatus(err)
+		}
+		page = &services.AuditPage{Meta: queryparams.PaginationMeta{CurrentPage: 1}}
+	}
+	renderData["Page"] = page
+
+	return renderer.Render(c, "dashboard/audit/list", "layouts/dashboard", renderData, status)
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"zatrano/pkg/logs"
 	"zatrano/pkg/renderer"
+	"zatrano/pkg/settings"
 	"zatrano/services"
 
 	"github.com/gofiber/fiber/v2"
@@ -11,25 +12,55 @@ import (
 )
 
 type DashboardHomeHandler struct {
-	userService services.IUserService
+	userService  services.IUserService
+	statsService services.IStatsService
 }
 
 func NewDashboardHomeHandler() *DashboardHomeHandler {
 	return &DashboardHomeHandler{
-		userService: services.NewUserService(),
+		userService:  services.NewUserService(),
+		statsService: services.NewStatsService(),
 	}
 }
 
 func (h *DashboardHomeHandler) HomePage(c *fiber.Ctx) error {
-	userCount, userErr := h.userService.GetUserCount()
+	userCount, userErr := h.userService.GetUserCount(c.UserContext())
 	if userErr != nil {
 		logs.Log.Error("Anasayfa: Kullanıcı sayısı alınamadı", zap.Error(userErr))
 		userCount = 0
 	}
 
+	var activeUserCount, newUserCount, loginCount int64
+	if breakdown, err := h.statsService.GetUserBreakdown(c.UserContext()); err != nil {
+		logs.Log.Error("Anasayfa: Kullanıcı dağılımı alınamadı", zap.Error(err))
+	} else {
+		activeUserCount = breakdown.Active
+	}
+	if registrations, err := h.statsService.GetUserRegistrations(c.UserContext(), services.DefaultStatsRangeDays, ""); err != nil {
+		logs.Log.Error("Anasayfa: Yeni kullanıcı sayısı alınamadı", zap.Error(err))
+	} else {
+		newUserCount = registrations.Total("Yeni Kullanıcı")
+	}
+	if logins, err := h.statsService.GetLoginActivity(c.UserContext(), services.DefaultStatsRangeDays); err != nil {
+		logs.Log.Error("Anasayfa: Giriş sayısı alınamadı", zap.Error(err))
+	} else {
+		loginCount = logins.Total("Giriş")
+	}
+
+	recentUsers, recentErr := h.statsService.GetRecentlyModifiedUsers(c.UserContext(), services.DefaultRecentLimit)
+	if recentErr != nil {
+		logs.Log.Error("Anasayfa: Son değiştirilen kullanıcılar alınamadı", zap.Error(recentErr))
+		recentUsers = []services.RecentUser{}
+	}
+
 	mapData := fiber.Map{
-		"Title":     "Dashboard",
-		"UserCount": userCount,
+		"Title":           settings.String(settings.DashboardHomeTitle),
+		"UserCount":       userCount,
+		"ActiveUserCount": activeUserCount,
+		"NewUserCount":    newUserCount,
+		"LoginCount":      loginCount,
+		"StatsRangeDays":  services.DefaultStatsRangeDays,
+		"RecentUsers":     recentUsers,
 	}
 	return renderer.Render(c, "dashboard/home/home", "layouts/dashboard", mapData, http.StatusOK)
 }
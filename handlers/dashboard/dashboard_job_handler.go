@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"net/http"
+
+	"zatrano/pkg/domainerrors"
+	"zatrano/pkg/flashmessages"
+	"zatrano/pkg/queryparams"
+	"zatrano/pkg/renderer"
+	"zatrano/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type JobHandler struct {
+	service services.IJobService
+}
+
+func NewJobHandler() *JobHandler {
+	return &JobHandler{service: services.NewJobService()}
+}
+
+func (h *JobHandler) ListJobs(c *fiber.Ctx) error {
+	status := c.Query("status")
+	renderData := fiber.Map{
+		"Title":    "Arka Plan İşleri",
+		"Status":   status,
+		"Statuses": services.JobStatuses,
+	}
+	code := http.StatusOK
+
+	page, err := h.service.List(c.UserContext(), status, c.QueryInt("page", 1), c.QueryInt("perPage", 0))
+	if err != nil {
+		renderData[renderer.FlashErrorKeyView] = domainerrors.UserMessage(err)
+		code = domainerrors.HTTPStatus(err)
+		page = &services.JobPage{Meta: queryparams.PaginationMeta{CurrentPage: 1}, Counts: map[string]int64{}}
+	}
+	renderData["Page"] = page
+
+	return renderer.Render(c, "dashboard/jobs/list", "layouts/dashboard", renderData, code)
+}
+
+func (h *JobHandler) RetryJob(c *fiber.Ctx) error {
+	redirectPath := "/dashboard/jobs?status=failed"
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz iş ID'si.")
+		return c.Redirect(redirectPath, fiber.StatusSeeOther)
+	}
+
+	if err := h.service.Retry(c.UserContext(), uint(id)); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, domainerrors.UserMessage(err))
+		return c.Redirect(redirectPath, fiber.StatusSeeOther)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "İş yeniden kuyruğa alındı.")
+	return c.Redirect(redirectPath, fiber.StatusSeeOther)
+}
@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"zatrano/models"
+	"zatrano/pkg/domainerrors"
+	"zatrano/pkg/flashmessages"
+	"zatrano/pkg/logs"
+	"zatrano/pkg/renderer"
+	"zatrano/pkg/sessions"
+	"zatrano/services"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type OrganizationHandler struct {
+	service services.IOrganizationService
+}
+
+func NewOrganizationHandler() *OrganizationHandler {
+	return &OrganizationHandler{service: services.NewOrganizationService()}
+}
+
+func (h *OrganizationHandler) ListOrganizations(c *fiber.Ctx) error {
+	return h.renderList(c, fiber.Map{}, fiber.StatusOK)
+}
+
+func (h *OrganizationHandler) CreateOrganization(c *fiber.Ctx) error {
+	name := c.FormValue("name")
+	organization, err := h.service.Create(c.UserContext(), name)
+	if err != nil {
+		logs.Log.Warn("Organizasyon oluşturulamadı", zap.String("name", name), zap.Error(err))
+		return h.renderList(c, fiber.Map{
+			renderer.FlashErrorKeyView: "Organizasyon oluşturulamadı: " + domainerrors.UserMessage(err),
+			renderer.FormDataKey:       fiber.Map{"Name": name},
+			renderer.FieldErrorsKey:    fieldErrorsFrom(err),
+		}, domainerrors.HTTPStatus(err))
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Organizasyon oluşturuldu: "+organization.Name)
+	return c.Redirect("/dashboard/organizations", fiber.StatusFound)
+}
+
+func (h *OrganizationHandler) UpdateOrganizationStatus(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz organizasyon ID'si.")
+		return c.Redirect("/dashboard/organizations", fiber.StatusSeeOther)
+	}
+
+	status := c.FormValue("status") == "true"
+	if current, ok := c.Locals(renderer.CurrentOrganization).(*models.Organization); ok && !status && current.ID == uint(id) {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Şu anda seçili olan organizasyon pasif hale getirilemez.")
+		return c.Redirect("/dashboard/organizations", fiber.StatusSeeOther)
+	}
+
+	if err := h.service.SetStatus(c.UserContext(), uint(id), status); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, domainerrors.UserMessage(err))
+		return c.Redirect("/dashboard/organizations", fiber.StatusSeeOther)
+	}
+
+	message := "Organizasyon pasif hale getirildi."
+	if status {
+		message = "Organizasyon aktif hale getirildi."
+	}
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, message)
+	return c.Redirect("/dashboard/organizations", fiber.StatusSeeOther)
+}
+
+func (h *OrganizationHandler) SwitchOrganization(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz organizasyon ID'si.")
+		return c.Redirect("/dashboard/home", fiber.StatusSeeOther)
+	}
+
+	organization, err := h.service.GetByID(uint(id))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, domainerrors.UserMessage(err))
+		return c.Redirect("/dashboard/home", fiber.StatusSeeOther)
+	}
+
+	sess, err := sessions.SessionStart(c)
+	if err != nil {
+		logs.Log.Error("Organizasyon değiştirilirken oturum alınamadı", zap.Error(err))
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Oturum bilgileri alınamadı.")
+		return c.Redirect("/dashboard/home", fiber.StatusSeeOther)
+	}
+	sess.Set("organization_id", organization.ID)
+	if err := sess.Save(); err != nil {
+		logs.Log.Error("Organizasyon değiştirilirken oturum kaydedilemedi", zap.Error(err))
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Oturum bilgileri kaydedilemedi.")
+		return c.Redirect("/dashboard/home", fiber.StatusSeeOther)
+	}
+
+	logs.Log.Info("Süper yönetici organizasyon değiştirdi",
+		zap.Any("user_id", c.UserContext().Value("user_id")),
+		zap.Uint("organization_id", organization.ID),
+	)
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Aktif organizasyon: "+organization.Name)
+	return c.Redirect("/dashboard/home", fiber.StatusSeeOther)
+}
+
+func (h *OrganizationHandler) renderList(c *fiber.Ctx, data fiber.Map, status int) error {
+	summaries, err := h.service.GetSummaries()
+	if err != nil {
+		data[renderer.FlashErrorKeyView] = domainerrors.UserMessage(err)
+		status = domainerrors.HTTPStatus(err)
+	}
+	data["Title"] = "Organizasyonlar"
+	data["Summaries"] = summaries
+	return renderer.Render(c, "dashboard/organizations/list", "layouts/dashboard", data, status)
+}
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"net/http"
+	"zatrano/pkg/domainerrors"
+	"zatrano/pkg/flashmessages"
+	"zatrano/pkg/logs"
+	"zatrano/pkg/renderer"
+	"zatrano/pkg/settings"
+	"zatrano/pkg/validation"
+	"zatrano/services"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type SettingHandler struct {
+	service services.ISettingService
+}
+
+func NewSettingHandler() *SettingHandler {
+	return &SettingHandler{service: services.NewSettingService()}
+}
+
+func (h *SettingHandler) ShowSettings(c *fiber.Ctx) error {
+	groups, err := h.service.GetGroups()
+	mapData := fiber.Map{
+		"Title":  "Ayarlar",
+		"Groups": groups,
+	}
+	if err != nil {
+		mapData[renderer.FlashErrorKeyView] = domainerrors.UserMessage(err)
+	}
+	return renderer.Render(c, "dashboard/settings/index", "layouts/dashboard", mapData)
+}
+
+func (h *SettingHandler) UpdateSettings(c *fiber.Ctx) error {
+	input := make(map[string]string)
+	for _, d := range settings.Definitions() {
+		if c.Request().PostArgs().Has(d.Key) {
+			input[d.Key] = c.FormValue(d.Key)
+		}
+	}
+
+	err := h.service.Update(c.UserContext(), input)
+	if err == nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Ayarlar başarıyla kaydedildi.")
+		return c.Redirect("/dashboard/settings", fiber.StatusFound)
+	}
+
+	groups, groupsErr := h.service.GetGroups()
+	if groupsErr != nil {
+		logs.Log.Error("Ayarlar: Form yeniden gösterilirken ayarlar alınamadı", zap.Error(groupsErr))
+	}
+	mapData := fiber.Map{
+		"Title":              "Ayarlar",
+		"Groups":             groups,
+		renderer.FormDataKey: input,
+	}
+
+	if errs, ok := err.(validation.Errors); ok {
+		mapData[renderer.FlashErrorKeyView] = formErrorMessage
+		mapData[renderer.FieldErrorsKey] = errs
+		return renderer.Render(c, "dashboard/settings/index", "layouts/dashboard", mapData, http.StatusBadRequest)
+	}
+
+	mapData[renderer.FlashErrorKeyView] = domainerrors.UserMessage(err)
+	return renderer.Render(c, "dashboard/settings/index", "layouts/dashboard", mapData, domainerrors.HTTPStatus(err))
+}
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"zatrano/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type DashboardStatsHandler struct {
+	statsService services.IStatsService
+}
+
+func NewDashboardStatsHandler() *DashboardStatsHandler {
+	return &DashboardStatsHandler{
+		statsService: services.NewStatsService(),
+	}
+}
+
+func (h *DashboardStatsHandler) UserBreakdown(c *fiber.Ctx) error {
+	breakdown, err := h.statsService.GetUserBreakdown(c.UserContext())
+	if err != nil {
+		return err
+	}
+	return c.JSON(breakdown)
+}
+
+func (h *DashboardStatsHandler) UserRegistrations(c *fiber.Ctx) error {
+	series, err := h.statsService.GetUserRegistrations(c.UserContext(), c.QueryInt("days", services.DefaultStatsRangeDays), c.Query("bucket"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(series)
+}
+
+func (h *DashboardStatsHandler) LoginActivity(c *fiber.Ctx) error {
+	series, err := h.statsService.GetLoginActivity(c.UserContext(), c.QueryInt("days", services.DefaultStatsRangeDays))
+	if err != nil {
+		return err
+	}
+	return c.JSON(series)
+}
+
+func (h *DashboardStatsHandler) RecentUsers(c *fiber.Ctx) error {
+	users, err := h.statsService.GetRecentlyModifiedUsers(c.UserContext(), c.QueryInt("limit", services.DefaultRecentLimit))
+	if err != nil {
+		return err
+	}
+	return c.JSON(fiber.Map{"data": users})
+}
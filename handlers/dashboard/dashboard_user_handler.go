@@ -2,25 +2,63 @@ package handlers
 
 import (
 	"errors"
+	"fmt"
+	"html/template"
 	"net/http"
+	"net/url"
+	"strconv"
 	"zatrano/models"
+	"zatrano/pkg/domainerrors"
 	"zatrano/pkg/flashmessages"
 	"zatrano/pkg/logs"
 	"zatrano/pkg/queryparams"
 	"zatrano/pkg/renderer"
+	"zatrano/pkg/validation"
 	"zatrano/services"
 
 	"github.com/gofiber/fiber/v2"
 	"go.uber.org/zap"
 )
 
+type UserCreateRequest struct {
+	Name     string `form:"name" validate:"required,max=100" label:"Ad Soyad"`
+	Account  string `form:"account" validate:"required,max=100,account" label:"Hesap Adı"`
+	Password string `form:"password" validate:"required,min=6" label:"Şifre"`
+	Status   string `form:"status"`
+	Type     string `form:"type" validate:"required,enum=user_type" label:"Kullanıcı Tipi"`
+
+	Email         string `form:"email" validate:"omitempty,max=150,email" label:"E-posta"`
+	Phone         string `form:"phone" validate:"omitempty,max=30" label:"Telefon"`
+	ManagerID     string `form:"manager_id" validate:"omitempty,integer" label:"Yönetici"`
+	OwnAgentsOnly string `form:"own_agents_only"`
+}
+
+type UserUpdateRequest struct {
+	Name     string `form:"name" validate:"required,max=100" label:"Ad Soyad"`
+	Account  string `form:"account" validate:"required,max=100,account" label:"Hesap Adı"`
+	Password string `form:"password" validate:"omitempty,min=6" label:"Şifre"`
+	Status   string `form:"status"`
+	Type     string `form:"type" validate:"required,enum=user_type" label:"Kullanıcı Tipi"`
+	Version  uint   `form:"version"`
+
+	Email         string `form:"email" validate:"omitempty,max=150,email" label:"E-posta"`
+	Phone         string `form:"phone" validate:"omitempty,max=30" label:"Telefon"`
+	ManagerID     string `form:"manager_id" validate:"omitempty,integer" label:"Yönetici"`
+	OwnAgentsOnly string `form:"own_agents_only"`
+}
+
+const formErrorMessage = "Lütfen formdaki hatalı alanları düzeltin."
+
 type UserHandler struct {
-	userService services.IUserService
+	userService  services.IUserService
+	auditService services.IAuditService
 }
 
 func NewUserHandler() *UserHandler {
+	validation.MustParse(UserCreateRequest{}, UserUpdateRequest{})
 	return &UserHandler{
-		userService: services.NewUserService(),
+		userService:  services.NewUserService(),
+		auditService: services.NewAuditService(),
 	}
 }
 
@@ -28,36 +66,23 @@ func (h *UserHandler) ListUsers(c *fiber.Ctx) error {
 	var params queryparams.ListParams
 	if err := c.QueryParser(&params); err != nil {
 		logs.Log.Warn("Kullanıcı listesi: Query parametreleri parse edilemedi, varsayılanlar kullanılıyor.", zap.Error(err))
-		params = queryparams.ListParams{
-			Page: queryparams.DefaultPage, PerPage: queryparams.DefaultPerPage,
-			SortBy: queryparams.DefaultSortBy, OrderBy: queryparams.DefaultOrderBy,
-		}
-	}
-
-	if params.Page <= 0 {
-		params.Page = queryparams.DefaultPage
+		params = queryparams.ListParams{}
 	}
-	if params.PerPage <= 0 {
-		params.PerPage = queryparams.DefaultPerPage
-	}
-	if params.PerPage > queryparams.MaxPerPage {
-		logs.Log.Warn("Sayfa başına istenen kayıt sayısı limiti aştı, varsayılana çekildi.",
-			zap.Int("requested", params.PerPage), zap.Int("max", queryparams.MaxPerPage), zap.Int("default", queryparams.DefaultPerPage))
-		params.PerPage = queryparams.DefaultPerPage
-	}
-	if params.SortBy == "" {
-		params.SortBy = queryparams.DefaultSortBy
-	}
-	if params.OrderBy == "" {
-		params.OrderBy = queryparams.DefaultOrderBy
+	params = services.NormalizeListParams(params)
+	if params.IsCursorMode() {
+		params.Mode = queryparams.CursorMode
 	}
+	owner := c.Query("owner")
 
-	paginatedResult, dbErr := h.userService.GetAllUsers(params)
+	paginatedResult, dbErr := h.userService.GetAllUsers(c.UserContext(), params, owner)
 
 	renderData := fiber.Map{
-		"Title":  "Kullanıcılar",
-		"Result": paginatedResult,
-		"Params": params,
+		"Title":      "Kullanıcılar",
+		"Result":     paginatedResult,
+		"Params":     params,
+		"Owner":      owner,
+		"OwnerQuery": ownerQuery(owner),
+		"Managers":   h.managers(c),
 	}
 	statusCode := http.StatusOK
 
@@ -80,18 +105,11 @@ func (h *UserHandler) ShowCreateUser(c *fiber.Ctx) error {
 	mapData := fiber.Map{
 		"Title": "Yeni Kullanıcı Ekle",
 	}
-	return renderer.Render(c, "dashboard/users/create", "layouts/dashboard", mapData)
+	return h.renderForm(c, "dashboard/users/create", mapData)
 }
 
 func (h *UserHandler) CreateUser(c *fiber.Ctx) error {
-	type Request struct {
-		Name     string `form:"name"`
-		Account  string `form:"account"`
-		Password string `form:"password"`
-		Status   string `form:"status"`
-		Type     string `form:"type"`
-	}
-	var req Request
+	var req UserCreateRequest
 
 	if err := c.BodyParser(&req); err != nil {
 		logs.SLog.Warnf("Kullanıcı oluşturma isteği ayrıştırılamadı: %v", err)
@@ -100,51 +118,42 @@ func (h *UserHandler) CreateUser(c *fiber.Ctx) error {
 			renderer.FlashErrorKeyView: "Geçersiz veri formatı veya eksik alanlar.",
 			renderer.FormDataKey:       req,
 		}
-		return renderer.Render(c, "dashboard/users/create", "layouts/dashboard", mapData, http.StatusBadRequest)
+		return h.renderForm(c, "dashboard/users/create", mapData, http.StatusBadRequest)
 	}
 
-	if req.Name == "" || req.Account == "" || req.Password == "" || req.Type == "" {
+	if errs := validation.Validate(req); errs != nil {
 		mapData := fiber.Map{
 			"Title":                    "Yeni Kullanıcı Ekle",
-			renderer.FlashErrorKeyView: "Ad, Hesap Adı, Şifre ve Kullanıcı Tipi alanları zorunludur.",
+			renderer.FlashErrorKeyView: formErrorMessage,
 			renderer.FormDataKey:       req,
+			renderer.FieldErrorsKey:    errs,
 		}
-		return renderer.Render(c, "dashboard/users/create", "layouts/dashboard", mapData, http.StatusBadRequest)
+		return h.renderForm(c, "dashboard/users/create", mapData, http.StatusBadRequest)
 	}
 
 	status := req.Status == "true"
 
 	user := models.User{
-		Name:     req.Name,
-		Account:  req.Account,
-		Password: req.Password,
-		Status:   status,
-		Type:     models.UserType(req.Type),
-	}
-
-	if user.Type != models.Dashboard && user.Type != models.Panel {
-		mapData := fiber.Map{
-			"Title":                    "Yeni Kullanıcı Ekle",
-			renderer.FlashErrorKeyView: "Geçersiz kullanıcı tipi seçildi.",
-			renderer.FormDataKey:       req,
-		}
-		return renderer.Render(c, "dashboard/users/create", "layouts/dashboard", mapData, http.StatusBadRequest)
+		Name:          req.Name,
+		Account:       req.Account,
+		Password:      req.Password,
+		Status:        status,
+		Type:          models.UserType(req.Type),
+		Email:         req.Email,
+		Phone:         req.Phone,
+		ManagerID:     parseManagerID(req.ManagerID),
+		OwnAgentsOnly: req.OwnAgentsOnly == "true",
 	}
 
 	if err := h.userService.CreateUser(c.UserContext(), &user); err != nil {
 		logs.Log.Error("Kullanıcı oluşturulamadı (Servis Hatası)", zap.String("account", req.Account), zap.Error(err))
-		errMsg := "Kullanıcı oluşturulamadı: " + err.Error()
-		statusCode := http.StatusInternalServerError
-		if errors.Is(err, errors.New("parola zorunlu")) || errors.Is(err, errors.New("parola şifreleme hatası")) {
-			statusCode = http.StatusBadRequest
-		}
-
 		mapData := fiber.Map{
 			"Title":                    "Yeni Kullanıcı Ekle",
-			renderer.FlashErrorKeyView: errMsg,
+			renderer.FlashErrorKeyView: "Kullanıcı oluşturulamadı: " + domainerrors.UserMessage(err),
 			renderer.FormDataKey:       req,
+			renderer.FieldErrorsKey:    fieldErrorsFrom(err),
 		}
-		return renderer.Render(c, "dashboard/users/create", "layouts/dashboard", mapData, statusCode)
+		return h.renderForm(c, "dashboard/users/create", mapData, domainerrors.HTTPStatus(err))
 	}
 
 	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Kullanıcı başarıyla oluşturuldu.")
@@ -160,10 +169,10 @@ func (h *UserHandler) ShowUpdateUser(c *fiber.Ctx) error {
 	}
 	userID := uint(id)
 
-	user, err := h.userService.GetUserByID(userID)
+	user, err := h.userService.GetUserByID(c.UserContext(), userID)
 	if err != nil {
 		var errMsg string
-		if errors.Is(err, errors.New("kayıt bulunamadı")) {
+		if domainerrors.IsNotFound(err) {
 			logs.Log.Warn("Kullanıcı güncelleme formu: Kullanıcı bulunamadı", zap.Uint("user_id", userID))
 			errMsg = "Düzenlenecek kullanıcı bulunamadı."
 		} else {
@@ -174,12 +183,18 @@ func (h *UserHandler) ShowUpdateUser(c *fiber.Ctx) error {
 		return c.Redirect("/dashboard/users", fiber.StatusSeeOther)
 	}
 
+	auditLogs, auditErr := h.auditService.ForEntity(c.UserContext(), "users", userID, services.DefaultEntityAuditLimit)
+	if auditErr != nil {
+		logs.Log.Warn("Kullanıcı güncelleme formu: Denetim kayıtları alınamadı", zap.Uint("user_id", userID), zap.Error(auditErr))
+	}
+
 	mapData := fiber.Map{
-		"Title": "Kullanıcı Düzenle",
-		"User":  user,
+		"Title":     "Kullanıcı Düzenle",
+		"User":      user,
+		"AuditLogs": auditLogs,
 	}
 
-	return renderer.Render(c, "dashboard/users/update", "layouts/dashboard", mapData)
+	return h.renderForm(c, "dashboard/users/update", mapData)
 }
 
 func (h *UserHandler) UpdateUser(c *fiber.Ctx) error {
@@ -192,84 +207,82 @@ func (h *UserHandler) UpdateUser(c *fiber.Ctx) error {
 	userID := uint(id)
 	redirectPathOnSuccess := "/dashboard/users"
 
-	type Request struct {
-		Name     string `form:"name"`
-		Account  string `form:"account"`
-		Password string `form:"password"`
-		Status   string `form:"status"`
-		Type     string `form:"type"`
-	}
-	var req Request
+	var req UserUpdateRequest
 
 	if err := c.BodyParser(&req); err != nil {
 		logs.Log.Warn("Kullanıcı güncelleme: Form verileri okunamadı", zap.Uint("user_id", userID), zap.Error(err))
-		user, _ := h.userService.GetUserByID(userID)
+		user, _ := h.userService.GetUserByID(c.UserContext(), userID)
 		mapData := fiber.Map{
 			"Title":                    "Kullanıcı Düzenle",
 			renderer.FlashErrorKeyView: "Form verileri okunamadı veya eksik.",
 			renderer.FormDataKey:       req,
 			"User":                     user,
 		}
-		return renderer.Render(c, "dashboard/users/update", "layouts/dashboard", mapData, http.StatusBadRequest)
+		return h.renderForm(c, "dashboard/users/update", mapData, http.StatusBadRequest)
 	}
 
-	if req.Name == "" || req.Account == "" || req.Type == "" {
-		user, _ := h.userService.GetUserByID(userID)
+	if errs := validation.Validate(req); errs != nil {
+		user, _ := h.userService.GetUserByID(c.UserContext(), userID)
 		mapData := fiber.Map{
 			"Title":                    "Kullanıcı Düzenle",
-			renderer.FlashErrorKeyView: "Ad, Hesap Adı ve Kullanıcı Tipi alanları zorunludur.",
+			renderer.FlashErrorKeyView: formErrorMessage,
 			renderer.FormDataKey:       req,
+			renderer.FieldErrorsKey:    errs,
 			"User":                     user,
 		}
-		return renderer.Render(c, "dashboard/users/update", "layouts/dashboard", mapData, http.StatusBadRequest)
+		return h.renderForm(c, "dashboard/users/update", mapData, http.StatusBadRequest)
 	}
 
 	userType := models.UserType(req.Type)
-	if userType != models.Dashboard && userType != models.Panel {
-		user, _ := h.userService.GetUserByID(userID)
-		mapData := fiber.Map{
-			"Title":                    "Kullanıcı Düzenle",
-			renderer.FlashErrorKeyView: "Geçersiz kullanıcı tipi seçildi.",
-			renderer.FormDataKey:       req,
-			"User":                     user,
-		}
-		return renderer.Render(c, "dashboard/users/update", "layouts/dashboard", mapData, http.StatusBadRequest)
-	}
 
 	status := req.Status == "true"
 
 	userUpdateData := &models.User{
-		Name:    req.Name,
-		Account: req.Account,
-		Status:  status,
-		Type:    userType,
+		Name:          req.Name,
+		Account:       req.Account,
+		Status:        status,
+		Type:          userType,
+		Email:         req.Email,
+		Phone:         req.Phone,
+		ManagerID:     parseManagerID(req.ManagerID),
+		OwnAgentsOnly: req.OwnAgentsOnly == "true",
 	}
+	userUpdateData.Version = req.Version
 	if req.Password != "" {
 		userUpdateData.Password = req.Password
 	}
 
 	if err := h.userService.UpdateUser(c.UserContext(), userID, userUpdateData); err != nil {
-		errMsg := "Kullanıcı güncellenemedi: " + err.Error()
-		statusCode := http.StatusInternalServerError
+		var conflictErr *services.UserVersionConflictError
+		if errors.As(err, &conflictErr) {
+			logs.Log.Warn("Kullanıcı güncelleme: Sürüm çakışması", zap.Uint("user_id", userID), zap.Uint("submitted_version", req.Version))
+			req.Version = conflictErr.Current.Version
+			mapData := fiber.Map{
+				"Title":                    "Kullanıcı Düzenle",
+				renderer.FlashErrorKeyView: "Bu kullanıcı siz düzenlerken başka bir yönetici tarafından güncellendi. Güncel değerleri kontrol edip tekrar kaydedin.",
+				renderer.FormDataKey:       req,
+				"User":                     conflictErr.Current,
+				"Conflict":                 conflictErr.Current,
+			}
+			return h.renderForm(c, "dashboard/users/update", mapData, http.StatusConflict)
+		}
 
-		if errors.Is(err, errors.New("kayıt bulunamadı")) {
+		if domainerrors.IsNotFound(err) {
 			logs.Log.Warn("Kullanıcı güncelleme: Kullanıcı bulunamadı (Servis hatası)", zap.Uint("user_id", userID))
-			errMsg = "Güncellenecek kullanıcı bulunamadı."
-			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, errMsg)
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Güncellenecek kullanıcı bulunamadı.")
 			return c.Redirect(redirectPathOnSuccess, fiber.StatusSeeOther)
-		} else if errors.Is(err, errors.New("parola güncelleme hatası")) || errors.Is(err, errors.New("parola şifreleme hatası")) {
-			statusCode = http.StatusBadRequest
 		}
 
 		logs.Log.Error("Kullanıcı güncelleme: Handler'da servis hatası yakalandı", zap.Uint("user_id", userID), zap.Error(err))
-		user, _ := h.userService.GetUserByID(userID)
+		user, _ := h.userService.GetUserByID(c.UserContext(), userID)
 		mapData := fiber.Map{
 			"Title":                    "Kullanıcı Düzenle",
-			renderer.FlashErrorKeyView: errMsg,
+			renderer.FlashErrorKeyView: "Kullanıcı güncellenemedi: " + domainerrors.UserMessage(err),
 			renderer.FormDataKey:       req,
+			renderer.FieldErrorsKey:    fieldErrorsFrom(err),
 			"User":                     user,
 		}
-		return renderer.Render(c, "dashboard/users/update", "layouts/dashboard", mapData, statusCode)
+		return h.renderForm(c, "dashboard/users/update", mapData, domainerrors.HTTPStatus(err))
 	}
 
 	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Kullanıcı başarıyla güncellendi.")
@@ -287,17 +300,91 @@ func (h *UserHandler) DeleteUser(c *fiber.Ctx) error {
 
 	if err := h.userService.DeleteUser(c.UserContext(), userID); err != nil {
 		var errMsg string
-		if errors.Is(err, errors.New("kayıt bulunamadı")) {
+		if domainerrors.IsNotFound(err) {
 			logs.Log.Warn("Kullanıcı silme: Kullanıcı bulunamadı", zap.Uint("user_id", userID))
 			errMsg = "Silinecek kullanıcı bulunamadı."
 		} else {
 			logs.Log.Error("Kullanıcı silme: Servis hatası", zap.Uint("user_id", userID), zap.Error(err))
-			errMsg = "Kullanıcı silinemedi: " + err.Error()
+			errMsg = "Kullanıcı silinemedi: " + domainerrors.UserMessage(err)
+		}
+		if renderer.WantsJSON(c) {
+			return err
 		}
 		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, errMsg)
 		return c.Redirect("/dashboard/users", fiber.StatusSeeOther)
 	}
 
+	if renderer.WantsJSON(c) {
+		return c.JSON(fiber.Map{"message": "Kullanıcı başarıyla silindi."})
+	}
 	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Kullanıcı başarıyla silindi.")
 	return c.Redirect("/dashboard/users", fiber.StatusFound)
 }
+
+func (h *UserHandler) ReassignUsers(c *fiber.Ctx) error {
+	var userIDs []uint
+	for _, raw := range c.Request().PostArgs().PeekMulti("user_ids") {
+		id, err := strconv.ParseUint(string(raw), 10, 64)
+		if err != nil || id == 0 {
+			logs.Log.Warn("Toplu sahip değişikliği: Geçersiz kullanıcı ID'si yok sayıldı", zap.ByteString("value", raw))
+			continue
+		}
+		userIDs = append(userIDs, uint(id))
+	}
+
+	var managerID uint
+	if managerIDPtr := parseManagerID(c.FormValue("manager_id")); managerIDPtr != nil {
+		managerID = *managerIDPtr
+	}
+
+	affected, err := h.userService.ReassignAgents(c.UserContext(), userIDs, managerID)
+	if err != nil {
+		logs.Log.Warn("Toplu sahip değişikliği başarısız", zap.Error(err))
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Sahip değiştirilemedi: "+domainerrors.UserMessage(err))
+		return c.Redirect("/dashboard/users", fiber.StatusSeeOther)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, fmt.Sprintf("%d kullanıcının sahibi güncellendi.", affected))
+	return c.Redirect("/dashboard/users", fiber.StatusFound)
+}
+
+func (h *UserHandler) renderForm(c *fiber.Ctx, tpl string, data fiber.Map, statusCode ...int) error {
+	data["Managers"] = h.managers(c)
+	return renderer.Render(c, tpl, "layouts/dashboard", data, statusCode...)
+}
+
+func (h *UserHandler) managers(c *fiber.Ctx) []models.User {
+	if restricted, _ := c.Locals(renderer.OwnAgentsOnly).(bool); restricted {
+		return nil
+	}
+	managers, err := h.userService.GetManagers(c.UserContext())
+	if err != nil {
+		logs.Log.Warn("Yönetici listesi alınamadı", zap.Error(err))
+		return nil
+	}
+	return managers
+}
+
+func parseManagerID(value string) *uint {
+	id, err := strconv.ParseUint(value, 10, 64)
+	if err != nil || id == 0 {
+		return nil
+	}
+	managerID := uint(id)
+	return &managerID
+}
+
+func ownerQuery(owner string) template.URL {
+	if owner == "" {
+		return ""
+	}
+	return template.URL("owner=" + url.QueryEscape(owner))
+}
+
+func fieldErrorsFrom(err error) validation.Errors {
+	field := domainerrors.FieldOf(err)
+	if field == "" {
+		return validation.Errors{}
+	}
+	return validation.Errors{field: domainerrors.UserMessage(err)}
+}
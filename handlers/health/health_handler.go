@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"zatrano/pkg/dbhealth"
+	"zatrano/pkg/replica"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type HealthHandler struct{}
+
+func NewHealthHandler() *HealthHandler {
+	return &HealthHandler{}
+}
+
+func (h *HealthHandler) Live(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{"status": "ok"})
+}
+
+func (h *HealthHandler) Ready(c *fiber.Ctx) error {
+	database := dbhealth.Snapshot()
+
+	replicas := fiber.Map{}
+	for _, pool := range replica.Pools() {
+		replicas[pool.Name] = pool.Healthy()
+	}
+
+	status, code := "ok", fiber.StatusOK
+	if !database.Healthy {
+		status, code = "unavailable", fiber.StatusServiceUnavailable
+	}
+	return c.Status(code).JSON(fiber.Map{
+		"status":   status,
+		"database": database,
+		"replicas": replicas,
+	})
+}
@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"net/http"
+	"zatrano/pkg/domainerrors"
+	"zatrano/pkg/flashmessages"
+	"zatrano/pkg/logs"
+	"zatrano/pkg/renderer"
+	"zatrano/services"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type NotificationHandler struct {
+	service  services.INotificationService
+	basePath string
+	layout   string
+}
+
+func NewNotificationHandler(basePath, layout string) *NotificationHandler {
+	return &NotificationHandler{
+		service:  services.NewNotificationService(),
+		basePath: basePath,
+		layout:   layout,
+	}
+}
+
+func (h *NotificationHandler) ListNotifications(c *fiber.Ctx) error {
+	userID, ok := c.UserContext().Value("user_id").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	unreadOnly := c.Query("filter") == "unread"
+	page, err := h.service.List(userID, unreadOnly, c.QueryInt("page", 1), c.QueryInt("perPage", 0))
+
+	renderData := fiber.Map{
+		"Title":      "Bildirimler",
+		"BasePath":   h.basePath,
+		"UnreadOnly": unreadOnly,
+		"Page":       page,
+	}
+	status := http.StatusOK
+	if err != nil {
+		renderData[renderer.FlashErrorKeyView] = domainerrors.UserMessage(err)
+		renderData["Page"] = &services.NotificationPage{}
+		status = domainerrors.HTTPStatus(err)
+	}
+
+	return renderer.Render(c, "notifications/list", h.layout, renderData, status)
+}
+
+func (h *NotificationHandler) MarkRead(c *fiber.Ctx) error {
+	userID, ok := c.UserContext().Value("user_id").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz bildirim ID'si.")
+		return c.Redirect(h.basePath, fiber.StatusSeeOther)
+	}
+
+	notification, err := h.service.MarkRead(userID, uint(id))
+	if err != nil {
+		logs.Log.Warn("Bildirim okundu olarak işaretlenemedi", zap.Uint("user_id", userID), zap.Int("id", id), zap.Error(err))
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, domainerrors.UserMessage(err))
+		return c.Redirect(h.basePath, fiber.StatusSeeOther)
+	}
+
+	if c.FormValue("open") == "true" && notification.Link != "" {
+		return c.Redirect(notification.Link, fiber.StatusSeeOther)
+	}
+	return c.Redirect(h.basePath, fiber.StatusSeeOther)
+}
+
+func (h *NotificationHandler) MarkAllRead(c *fiber.Ctx) error {
+	userID, ok := c.UserContext().Value("user_id").(uint)
+	if !ok || userID == 0 {
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	count, err := h.service.MarkAllRead(userID)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, domainerrors.UserMessage(err))
+	} else if count > 0 {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Tüm bildirimler okundu olarak işaretlendi.")
+	}
+	return c.Redirect(h.basePath, fiber.StatusSeeOther)
+}
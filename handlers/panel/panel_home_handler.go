@@ -2,14 +2,36 @@ package handlers
 
 import (
 	"net/http"
+	"zatrano/pkg/logs"
 	"zatrano/pkg/renderer"
+	"zatrano/pkg/settings"
+	"zatrano/services"
 
 	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
 )
 
-func PanelHomeHandler(c *fiber.Ctx) error {
+type PanelHomeHandler struct {
+	userService services.IUserService
+}
+
+func NewPanelHomeHandler() *PanelHomeHandler {
+	return &PanelHomeHandler{userService: services.NewUserService()}
+}
+
+func (h *PanelHomeHandler) HomePage(c *fiber.Ctx) error {
 	mapData := fiber.Map{
-		"Title": "Aracı Ana Sayfa",
+		"Title": settings.String(settings.PanelHomeTitle),
+	}
+
+	userID, _ := c.UserContext().Value("user_id").(uint)
+	user, err := h.userService.GetUserByID(c.UserContext(), userID)
+	if err != nil {
+		logs.Log.Error("Aracı ana sayfası: Kullanıcı bilgileri alınamadı", zap.Uint("user_id", userID), zap.Error(err))
+		mapData[renderer.FlashErrorKeyView] = "Hesap bilgileriniz alınamadı."
+	} else {
+		mapData["User"] = user
+		mapData["Manager"] = user.Manager
 	}
 
 	return renderer.Render(c, "panel/home/home", "layouts/panel", mapData, http.StatusOK)
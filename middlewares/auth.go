@@ -2,10 +2,17 @@ package middlewares
 
 import (
 	"context"
+	"zatrano/pkg/audit"
+	"zatrano/pkg/domainerrors"
+	"zatrano/pkg/logs"
+	"zatrano/pkg/ownership"
+	"zatrano/pkg/renderer"
 	"zatrano/pkg/sessions"
+	"zatrano/pkg/tenant"
 	"zatrano/services"
 
 	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
 )
 
 func AuthMiddleware(c *fiber.Ctx) error {
@@ -20,14 +27,45 @@ func AuthMiddleware(c *fiber.Ctx) error {
 	}
 
 	authService := services.NewAuthService()
-	_, err = authService.GetUserProfile(userID)
+	user, err := authService.GetUserProfile(c.UserContext(), userID)
+	if domainerrors.IsUnavailable(err) {
+		return RenderDatabaseUnavailable(c)
+	}
+	if err != nil {
+		_ = sess.Destroy()
+		return c.Redirect("/auth/login")
+	}
+
+	organizationService := services.NewOrganizationService()
+	organization, err := organizationService.ResolveCurrent(user, sessions.GetOrganizationIDFromSession(sess))
 	if err != nil {
+		logs.Log.Warn("Kullanıcının organizasyonu belirlenemedi, oturum kapatılıyor", zap.Uint("user_id", userID), zap.Error(err))
 		_ = sess.Destroy()
 		return c.Redirect("/auth/login")
 	}
 
-	ctx := context.WithValue(c.Context(), "user_id", userID)
+	c.Locals("userID", userID)
+	if user.MustChangePassword {
+		c.Locals(mustChangePasswordKey, true)
+	}
+
+	ctx := context.WithValue(c.UserContext(), "user_id", userID)
+	ctx = tenant.WithOrganization(ctx, organization.ID)
+	if user.RestrictedToOwnAgents() {
+		ctx = ownership.WithManager(ctx, user.ID)
+		c.Locals(renderer.OwnAgentsOnly, true)
+	}
+	requestID, _ := c.Locals("requestid").(string)
+	ctx = audit.WithRequestInfo(ctx, c.IP(), requestID)
 	c.SetUserContext(ctx)
 
+	c.Locals(renderer.CurrentOrganization, organization)
+	if user.SuperAdmin {
+		c.Locals(renderer.SuperAdmin, true)
+		if organizations, err := organizationService.GetAll(); err == nil {
+			c.Locals(renderer.Organizations, organizations)
+		}
+	}
+
 	return c.Next()
 }
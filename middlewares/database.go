@@ -0,0 +1,39 @@
+package middlewares
+
+import (
+	"strconv"
+
+	"zatrano/pkg/dbhealth"
+	"zatrano/pkg/renderer"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	databaseUnavailableMessage = "Sistem kısa süreliğine hizmet veremiyor. Lütfen birkaç saniye sonra tekrar deneyin."
+	databaseRetryAfterSeconds  = 5
+)
+
+func DatabaseAvailabilityMiddleware(c *fiber.Ctx) error {
+	if dbhealth.Healthy() {
+		return c.Next()
+	}
+	return RenderDatabaseUnavailable(c)
+}
+
+func RenderDatabaseUnavailable(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderRetryAfter, strconv.Itoa(databaseRetryAfterSeconds))
+	if renderer.WantsJSON(c) {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": databaseUnavailableMessage})
+	}
+
+	retryPath := c.OriginalURL()
+	if c.Method() != fiber.MethodGet {
+		retryPath = c.Get(fiber.HeaderReferer, "/")
+	}
+	return renderer.Render(c, "errors/database_unavailable", "layouts/auth", fiber.Map{
+		"Title":     "Veritabanına ulaşılamıyor",
+		"Message":   databaseUnavailableMessage,
+		"RetryPath": retryPath,
+	}, fiber.StatusServiceUnavailable)
+}
@@ -0,0 +1,14 @@
+package middlewares
+
+import (
+	"zatrano/pkg/renderer"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func FullAccessMiddleware(c *fiber.Ctx) error {
+	if restricted, _ := c.Locals(renderer.OwnAgentsOnly).(bool); restricted {
+		return c.Status(fiber.StatusForbidden).SendString("Bu işlem için yetkiniz yok")
+	}
+	return c.Next()
+}
@@ -20,7 +20,7 @@ func GuestMiddleware(c *fiber.Ctx) error {
 	}
 
 	authService := services.NewAuthService()
-	user, err := authService.GetUserProfile(userID)
+	user, err := authService.GetUserProfile(c.UserContext(), userID)
 	if err != nil {
 		_ = sess.Destroy()
 		return c.Next()
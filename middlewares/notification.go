@@ -0,0 +1,16 @@
+package middlewares
+
+import (
+	"zatrano/pkg/renderer"
+	"zatrano/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func NotificationMiddleware(c *fiber.Ctx) error {
+	userID, ok := c.UserContext().Value("user_id").(uint)
+	if ok && userID != 0 {
+		c.Locals(renderer.UnreadNotifications, services.NewNotificationService().UnreadCount(userID))
+	}
+	return c.Next()
+}
@@ -0,0 +1,14 @@
+package middlewares
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+const mustChangePasswordKey = "must_change_password"
+
+func PasswordChangeMiddleware(c *fiber.Ctx) error {
+	if required, _ := c.Locals(mustChangePasswordKey).(bool); required {
+		return c.Redirect("/auth/change-password")
+	}
+	return c.Next()
+}
@@ -0,0 +1,35 @@
+package middlewares
+
+import (
+	"time"
+
+	"zatrano/pkg/replica"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const primaryAfterWriteCookie = "db_primary"
+
+func ReplicaRoutingMiddleware(c *fiber.Ctx) error {
+	if !replica.Enabled() {
+		return c.Next()
+	}
+
+	write := c.Method() != fiber.MethodGet && c.Method() != fiber.MethodHead
+	if write || c.Cookies(primaryAfterWriteCookie) != "" {
+		c.SetUserContext(replica.ForcePrimary(c.UserContext()))
+	}
+
+	err := c.Next()
+	if write && err == nil && c.Response().StatusCode() < fiber.StatusBadRequest {
+		c.Cookie(&fiber.Cookie{
+			Name:     primaryAfterWriteCookie,
+			Value:    "1",
+			Path:     "/",
+			Expires:  time.Now().Add(replica.Stickiness()),
+			HTTPOnly: true,
+			SameSite: fiber.CookieSameSiteLaxMode,
+		})
+	}
+	return err
+}
@@ -0,0 +1,32 @@
+package middlewares
+
+import (
+	"zatrano/pkg/sessions"
+	"zatrano/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func SuperAdminMiddleware(c *fiber.Ctx) error {
+	sess, err := sessions.SessionStart(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).SendString("Oturum açılmamış")
+	}
+
+	userID, err := sessions.GetUserIDFromSession(sess)
+	if err != nil {
+		return c.Status(fiber.StatusForbidden).SendString("Yetkisiz erişim")
+	}
+
+	authService := services.NewAuthService()
+	user, err := authService.GetUserProfile(c.UserContext(), userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).SendString("Kullanıcı bilgileri alınamadı")
+	}
+
+	if !user.SuperAdmin {
+		return c.Status(fiber.StatusForbidden).SendString("Bu işlem için yetkiniz yok")
+	}
+
+	return c.Next()
+}
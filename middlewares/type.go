@@ -21,7 +21,7 @@ func TypeMiddleware(requiredType models.UserType) fiber.Handler {
 		}
 
 		authService := services.NewAuthService()
-		user, err := authService.GetUserProfile(userID)
+		user, err := authService.GetUserProfile(c.UserContext(), userID)
 		if err != nil {
 			return c.Status(fiber.StatusInternalServerError).SendString("Kullanıcı bilgileri alınamadı")
 		}
@@ -0,0 +1,112 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+type AuditChange struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+func (c AuditChange) OldText() string {
+	return auditValueText(c.Old)
+}
+
+func (c AuditChange) NewText() string {
+	return auditValueText(c.New)
+}
+
+func auditValueText(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "—"
+	case bool:
+		if v {
+			return "Evet"
+		}
+		return "Hayır"
+	case string:
+		if v == "" {
+			return "(boş)"
+		}
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+type AuditChanges map[string]AuditChange
+
+func (c AuditChanges) Fields() []string {
+	fields := make([]string, 0, len(c))
+	for field := range c {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+func (c AuditChanges) Value() (driver.Value, error) {
+	if c == nil {
+		return "{}", nil
+	}
+	encoded, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	return string(encoded), nil
+}
+
+func (c *AuditChanges) Scan(value interface{}) error {
+	var raw []byte
+	switch v := value.(type) {
+	case nil:
+		*c = AuditChanges{}
+		return nil
+	case []byte:
+		raw = v
+	case string:
+		raw = []byte(v)
+	default:
+		return errors.New("AuditChanges: desteklenmeyen veri tipi")
+	}
+	changes := AuditChanges{}
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &changes); err != nil {
+			return err
+		}
+	}
+	*c = changes
+	return nil
+}
+
+type AuditLog struct {
+	ID             uint         `gorm:"primarykey"`
+	ActorID        *uint        `gorm:"index"`
+	OrganizationID *uint        `gorm:"index"`
+	Action         string       `gorm:"size:20;not null;index"`
+	EntityType     string       `gorm:"size:50;not null;index:idx_audit_logs_entity,priority:1"`
+	EntityID       uint         `gorm:"not null;index:idx_audit_logs_entity,priority:2"`
+	Changes        AuditChanges `gorm:"type:text"`
+	IP             string       `gorm:"size:45"`
+	RequestID      string       `gorm:"size:64;index"`
+	CreatedAt      time.Time    `gorm:"not null;index"`
+}
+
+func (a AuditLog) ActionLabel() string {
+	switch a.Action {
+	case "create":
+		return "Oluşturma"
+	case "update":
+		return "Güncelleme"
+	case "delete":
+		return "Silme"
+	}
+	return a.Action
+}
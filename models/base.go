@@ -23,9 +23,13 @@ type BaseModel struct {
 	CreatedBy uint
 	UpdatedBy uint
 	DeletedBy *uint `gorm:"column:deleted_by"`
+	Version   uint  `gorm:"not null;default:1"`
 }
 
 func (b *BaseModel) BeforeCreate(tx *gorm.DB) (err error) {
+	if b.Version == 0 {
+		b.Version = 1
+	}
 	userID, ok := tx.Statement.Context.Value(contextUserIDKey).(uint)
 	if ok && userID != 0 {
 		b.CreatedBy = userID
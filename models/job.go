@@ -0,0 +1,62 @@
+package models
+
+import "time"
+
+const (
+	JobPending   = "pending"
+	JobRunning   = "running"
+	JobCompleted = "completed"
+	JobFailed    = "failed"
+)
+
+type Job struct {
+	ID          uint       `gorm:"primarykey"`
+	Type        string     `gorm:"size:100;not null;index"`
+	Payload     string     `gorm:"type:text;not null"`
+	Status      string     `gorm:"size:20;not null;default:'pending';index:idx_jobs_status_run_at,priority:1"`
+	Attempts    int        `gorm:"not null;default:0"`
+	MaxAttempts int        `gorm:"not null;default:5"`
+	RunAt       time.Time  `gorm:"not null;index:idx_jobs_status_run_at,priority:2"`
+	LockedBy    string     `gorm:"size:100"`
+	LockedAt    *time.Time `gorm:"index"`
+	LastError   string     `gorm:"type:text"`
+	CompletedAt *time.Time
+	FailedAt    *time.Time
+	CreatedBy   *uint
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (j Job) StatusLabel() string {
+	switch j.Status {
+	case JobPending:
+		if j.Attempts > 0 {
+			return "Tekrar Denenecek"
+		}
+		return "Bekliyor"
+	case JobRunning:
+		return "Çalışıyor"
+	case JobCompleted:
+		return "Tamamlandı"
+	case JobFailed:
+		return "Başarısız"
+	}
+	return j.Status
+}
+
+func (j Job) StatusClass() string {
+	switch j.Status {
+	case JobPending:
+		if j.Attempts > 0 {
+			return "warning"
+		}
+		return "secondary"
+	case JobRunning:
+		return "info"
+	case JobCompleted:
+		return "success"
+	case JobFailed:
+		return "danger"
+	}
+	return "light"
+}
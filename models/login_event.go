@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+type LoginEvent struct {
+	ID        uint      `gorm:"primarykey"`
+	UserID    uint      `gorm:"not null;index"`
+	IP        string    `gorm:"size:45"`
+	UserAgent string    `gorm:"size:255"`
+	CreatedAt time.Time `gorm:"not null;index"`
+}
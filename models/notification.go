@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+const (
+	NotificationInfo    = "info"
+	NotificationSuccess = "success"
+	NotificationWarning = "warning"
+	NotificationDanger  = "danger"
+)
+
+type Notification struct {
+	ID        uint       `gorm:"primarykey"`
+	UserID    uint       `gorm:"not null;index:idx_notifications_user_created,priority:1"`
+	Title     string     `gorm:"size:150;not null"`
+	Body      string     `gorm:"type:text"`
+	Link      string     `gorm:"size:255"`
+	Level     string     `gorm:"size:20;not null;default:'info'"`
+	ReadAt    *time.Time `gorm:"index"`
+	CreatedAt time.Time  `gorm:"not null;index:idx_notifications_user_created,priority:2"`
+}
+
+func (n Notification) IsRead() bool {
+	return n.ReadAt != nil
+}
+
+func (n Notification) Icon() string {
+	switch n.Level {
+	case NotificationSuccess:
+		return "bi-check-circle-fill text-success"
+	case NotificationWarning:
+		return "bi-exclamation-triangle-fill text-warning"
+	case NotificationDanger:
+		return "bi-x-octagon-fill text-danger"
+	}
+	return "bi-info-circle-fill text-primary"
+}
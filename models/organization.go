@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"zatrano/pkg/domainerrors"
+)
+
+const DefaultOrganizationName = "Varsayılan Organizasyon"
+
+type Organization struct {
+	ID        uint   `gorm:"primarykey"`
+	Name      string `gorm:"size:150;not null;uniqueIndex"`
+	Status    bool   `gorm:"not null;default:true;index"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (Organization) TranslateDBError(err error) error {
+	if domainerrors.IsConflict(err) && domainerrors.FieldOf(err) == "name" {
+		return domainerrors.WithMessage(err, "bu isimde bir organizasyon zaten var")
+	}
+	return err
+}
@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+type Setting struct {
+	ID        uint   `gorm:"primarykey"`
+	Key       string `gorm:"size:100;not null;uniqueIndex"`
+	Value     string `gorm:"type:text;not null;default:''"`
+	UpdatedBy *uint
+	UpdatedAt time.Time
+}
@@ -1,6 +1,9 @@
 package models
 
 import (
+	"zatrano/pkg/domainerrors"
+	"zatrano/pkg/validation"
+
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 	"gorm.io/gorm/schema"
@@ -13,6 +16,10 @@ const (
 	Panel     UserType = "panel"
 )
 
+func init() {
+	validation.RegisterEnum("user_type", string(Dashboard), string(Panel))
+}
+
 func (UserType) GormDataType() string {
 	return "user_type"
 }
@@ -25,11 +32,43 @@ func (UserType) GormDBDataType(db *gorm.DB, field *schema.Field) string {
 
 type User struct {
 	BaseModel
-	Name     string   `gorm:"size:100;not null;index"`
-	Account  string   `gorm:"size:100;unique;not null"`
-	Password string   `gorm:"size:255;not null"`
-	Status   bool     `gorm:"default:true;index"`
-	Type     UserType `gorm:"type:user_type;not null;default:'panel';index"`
+	Name      string   `gorm:"size:100;not null;index"`
+	Account   string   `gorm:"size:100;unique;not null"`
+	Password  string   `gorm:"size:255;not null"`
+	Status    bool     `gorm:"default:true;index"`
+	Type      UserType `gorm:"type:user_type;not null;default:'panel';index"`
+	Protected bool     `gorm:"not null;default:false"`
+
+	MustChangePassword bool   `gorm:"not null;default:false"`
+	Email              string `gorm:"size:150"`
+	Phone              string `gorm:"size:30"`
+
+	OrganizationID uint          `gorm:"not null;index"`
+	Organization   *Organization `gorm:"constraint:OnUpdate:CASCADE,OnDelete:RESTRICT"`
+	SuperAdmin     bool          `gorm:"not null;default:false"`
+
+	ManagerID     *uint `gorm:"index"`
+	Manager       *User `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+	OwnAgentsOnly bool  `gorm:"not null;default:false"`
+}
+
+func (User) SortableColumns() []string {
+	return []string{"id", "name", "account", "created_at", "status", "type"}
+}
+
+func (User) SearchColumns() []string {
+	return []string{"name", "account"}
+}
+
+func (User) TranslateDBError(err error) error {
+	if domainerrors.IsConflict(err) && domainerrors.FieldOf(err) == "account" {
+		return domainerrors.WithMessage(err, "bu hesap adı zaten kullanılıyor")
+	}
+	return err
+}
+
+func (u *User) RestrictedToOwnAgents() bool {
+	return u.Type == Dashboard && u.OwnAgentsOnly
 }
 
 func (u *User) CheckPassword(password string) error {
@@ -0,0 +1,250 @@
+package audit
+
+import (
+	"context"
+	"database/sql/driver"
+	"reflect"
+	"sync"
+	"time"
+
+	"zatrano/models"
+	"zatrano/pkg/redact"
+	"zatrano/pkg/tenant"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+)
+
+type Redactor interface {
+	AuditRedactedFields() []string
+}
+
+type Entry struct {
+	Action     string
+	EntityType string
+	EntityID   uint
+	Changes    models.AuditChanges
+}
+
+type requestInfoKey struct{}
+
+type RequestInfo struct {
+	IP        string
+	RequestID string
+}
+
+var (
+	schemaCache      = &sync.Map{}
+	sensitiveColumns = map[string]bool{
+		"password": true, "password_hash": true, "token": true, "secret": true,
+		"api_key": true, "remember_token": true, "reset_token": true,
+	}
+	ignoredColumns = map[string]bool{
+		"id": true, "created_at": true, "updated_at": true, "deleted_at": true,
+		"created_by": true, "updated_by": true, "deleted_by": true, "version": true,
+	}
+)
+
+func WithRequestInfo(ctx context.Context, ip, requestID string) context.Context {
+	return context.WithValue(ctx, requestInfoKey{}, RequestInfo{IP: ip, RequestID: requestID})
+}
+
+func RequestInfoFrom(ctx context.Context) RequestInfo {
+	if ctx == nil {
+		return RequestInfo{}
+	}
+	info, _ := ctx.Value(requestInfoKey{}).(RequestInfo)
+	return info
+}
+
+func Record(ctx context.Context, db *gorm.DB, entry Entry) error {
+	if entry.Action == ActionUpdate && len(entry.Changes) == 0 {
+		return nil
+	}
+
+	var actorID *uint
+	if userID, ok := ctx.Value("user_id").(uint); ok && userID != 0 {
+		actorID = &userID
+	}
+	var organizationID *uint
+	if id, ok := tenant.OrganizationID(ctx); ok {
+		organizationID = &id
+	}
+	info := RequestInfoFrom(ctx)
+
+	log := models.AuditLog{
+		ActorID:        actorID,
+		OrganizationID: organizationID,
+		Action:         entry.Action,
+		EntityType:     entry.EntityType,
+		EntityID:       entry.EntityID,
+		Changes:        entry.Changes,
+		IP:             info.IP,
+		RequestID:      info.RequestID,
+	}
+	return db.WithContext(ctx).Create(&log).Error
+}
+
+func Diff(db *gorm.DB, before, after interface{}) (models.AuditChanges, error) {
+	model := after
+	if model == nil {
+		model = before
+	}
+	if model == nil {
+		return models.AuditChanges{}, nil
+	}
+	sch, err := schema.Parse(model, schemaCache, db.NamingStrategy)
+	if err != nil {
+		return nil, err
+	}
+
+	redacted := redactedColumns(model)
+	oldValues := snapshot(sch, before)
+	newValues := snapshot(sch, after)
+
+	changes := models.AuditChanges{}
+	for _, field := range sch.Fields {
+		column := field.DBName
+		if column == "" || ignoredColumns[column] {
+			continue
+		}
+		oldValue, hadOld := oldValues[column]
+		newValue, hasNew := newValues[column]
+		if hadOld && hasNew && reflect.DeepEqual(oldValue, newValue) {
+			continue
+		}
+		if !hadOld && isEmpty(newValue) || !hasNew && isEmpty(oldValue) {
+			continue
+		}
+
+		change := models.AuditChange{Old: oldValue, New: newValue}
+		if redacted[column] {
+			change = models.AuditChange{}
+			if hadOld && !isEmpty(oldValue) {
+				change.Old = redact.Value
+			}
+			if hasNew && !isEmpty(newValue) {
+				change.New = redact.Value
+			}
+		}
+		changes[column] = change
+	}
+	return changes, nil
+}
+
+func PrimaryKey(db *gorm.DB, value interface{}) uint {
+	sch, err := schema.Parse(value, schemaCache, db.NamingStrategy)
+	if err != nil || sch.PrioritizedPrimaryField == nil {
+		return 0
+	}
+	id, _ := sch.PrioritizedPrimaryField.ValueOf(context.Background(), reflect.Indirect(reflect.ValueOf(value)))
+	switch v := id.(type) {
+	case uint:
+		return v
+	case uint64:
+		return uint(v)
+	case int:
+		return uint(v)
+	case int64:
+		return uint(v)
+	}
+	return 0
+}
+
+func TableName(db *gorm.DB, value interface{}) string {
+	sch, err := schema.Parse(value, schemaCache, db.NamingStrategy)
+	if err != nil {
+		return ""
+	}
+	return sch.Table
+}
+
+func snapshot(sch *schema.Schema, value interface{}) map[string]interface{} {
+	values := map[string]interface{}{}
+	if value == nil {
+		return values
+	}
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return values
+	}
+	rv = reflect.Indirect(rv)
+	for _, field := range sch.Fields {
+		if field.DBName == "" || ignoredColumns[field.DBName] {
+			continue
+		}
+		fieldValue, _ := field.ValueOf(context.Background(), rv)
+		values[field.DBName] = normalize(fieldValue)
+	}
+	return values
+}
+
+func normalize(value interface{}) interface{} {
+	rv := reflect.ValueOf(value)
+	for rv.IsValid() && rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+		value = rv.Interface()
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case time.Time:
+		if v.IsZero() {
+			return nil
+		}
+		return v.UTC().Format(time.RFC3339)
+	case driver.Valuer:
+		converted, err := v.Value()
+		if err != nil {
+			return nil
+		}
+		return normalize(converted)
+	}
+
+	switch rv.Kind() {
+	case reflect.String:
+		return rv.String()
+	case reflect.Bool:
+		return rv.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		return rv.Float()
+	}
+	return value
+}
+
+func isEmpty(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	if s, ok := value.(string); ok {
+		return s == ""
+	}
+	return false
+}
+
+func redactedColumns(model interface{}) map[string]bool {
+	columns := make(map[string]bool, len(sensitiveColumns))
+	for column := range sensitiveColumns {
+		columns[column] = true
+	}
+	if redactor, ok := model.(Redactor); ok {
+		for _, column := range redactor.AuditRedactedFields() {
+			columns[column] = true
+		}
+	}
+	return columns
+}
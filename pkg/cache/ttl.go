@@ -0,0 +1,67 @@
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+type entry struct {
+	value     interface{}
+	expiresAt time.Time
+}
+
+type TTL struct {
+	mu    sync.RWMutex
+	ttl   time.Duration
+	items map[string]entry
+}
+
+func NewTTL(ttl time.Duration) *TTL {
+	return &TTL{ttl: ttl, items: map[string]entry{}}
+}
+
+func (c *TTL) Get(key string) (interface{}, bool) {
+	c.mu.RLock()
+	item, ok := c.items[key]
+	c.mu.RUnlock()
+	if !ok || time.Now().After(item.expiresAt) {
+		return nil, false
+	}
+	return item.value, true
+}
+
+func (c *TTL) Set(key string, value interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	for k, item := range c.items {
+		if now.After(item.expiresAt) {
+			delete(c.items, k)
+		}
+	}
+	c.items[key] = entry{value: value, expiresAt: now.Add(c.ttl)}
+}
+
+func (c *TTL) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.items, key)
+}
+
+func (c *TTL) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.items = map[string]entry{}
+}
+
+func (c *TTL) Remember(key string, load func() (interface{}, error)) (interface{}, error) {
+	if value, ok := c.Get(key); ok {
+		return value, nil
+	}
+	value, err := load()
+	if err != nil {
+		return nil, err
+	}
+	c.Set(key, value)
+	return value, nil
+}
@@ -0,0 +1,119 @@
+package dbhealth
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"zatrano/pkg/logs"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const pingTimeout = 2 * time.Second
+
+type Status struct {
+	Healthy   bool      `json:"healthy"`
+	Since     time.Time `json:"since"`
+	LastCheck time.Time `json:"last_check"`
+	Error     string    `json:"error,omitempty"`
+}
+
+var (
+	healthy atomic.Bool
+	mu      sync.RWMutex
+	status  Status
+	stopped chan struct{}
+)
+
+func Healthy() bool {
+	return healthy.Load()
+}
+
+func Snapshot() Status {
+	mu.RLock()
+	defer mu.RUnlock()
+	return status
+}
+
+func Ping(db *gorm.DB, timeout time.Duration) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return sqlDB.PingContext(ctx)
+}
+
+func Check(db *gorm.DB) bool {
+	err := Ping(db, pingTimeout)
+	set(err)
+	return err == nil
+}
+
+func Start(db *gorm.DB, interval time.Duration) {
+	mu.Lock()
+	if stopped != nil {
+		mu.Unlock()
+		return
+	}
+	stopped = make(chan struct{})
+	done := stopped
+	mu.Unlock()
+
+	Check(db)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				Check(db)
+			case <-done:
+				return
+			}
+		}
+	}()
+}
+
+func Stop() {
+	mu.Lock()
+	defer mu.Unlock()
+	if stopped != nil {
+		close(stopped)
+		stopped = nil
+	}
+	healthy.Store(false)
+	status = Status{}
+}
+
+func set(err error) {
+	now := time.Now().UTC()
+	up := err == nil
+
+	mu.Lock()
+	changed := status.Since.IsZero() || status.Healthy != up
+	status.LastCheck = now
+	status.Healthy = up
+	status.Error = ""
+	if err != nil {
+		status.Error = err.Error()
+	}
+	if changed {
+		status.Since = now
+	}
+	mu.Unlock()
+
+	if healthy.Swap(up) == up {
+		return
+	}
+	if up {
+		logs.Log.Info("Veritabanı bağlantısı kullanılabilir durumda")
+	} else {
+		logs.Log.Error("Veritabanına ulaşılamıyor", zap.Error(err))
+	}
+}
@@ -0,0 +1,107 @@
+package domainerrors
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net"
+	"regexp"
+	"syscall"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+const (
+	pgUniqueViolation     = "23505"
+	pgForeignKeyViolation = "23503"
+	pgNotNullViolation    = "23502"
+	pgCheckViolation      = "23514"
+	pgInvalidTextRepr     = "22P02"
+	pgStringTooLong       = "22001"
+)
+
+var (
+	pgDetailKeyPattern      = regexp.MustCompile(`Key \(([^)]+)\)`)
+	sqliteConstraintPattern = regexp.MustCompile(`(UNIQUE|FOREIGN KEY|NOT NULL|CHECK) constraint failed(?:: (?:\w+\.)?(\w+))?`)
+)
+
+func FromDB(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var de *Error
+	if errors.As(err, &de) {
+		return err
+	}
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Wrap(err, KindNotFound, "kayıt bulunamadı")
+	}
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return Wrap(err, KindConflict, "bu kayıt zaten mevcut")
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case pgUniqueViolation:
+			return Wrap(err, KindConflict, "bu değer zaten kullanılıyor").WithField(pgColumn(pgErr))
+		case pgForeignKeyViolation:
+			return Wrap(err, KindConflict, "kayıt başka kayıtlarla ilişkili olduğu için işlem yapılamadı").WithField(pgColumn(pgErr))
+		case pgNotNullViolation:
+			return Wrap(err, KindValidation, "zorunlu bir alan boş bırakıldı").WithField(pgErr.ColumnName)
+		case pgCheckViolation, pgInvalidTextRepr:
+			return Wrap(err, KindValidation, "geçersiz bir değer girildi").WithField(pgErr.ColumnName)
+		case pgStringTooLong:
+			return Wrap(err, KindValidation, "girilen değer çok uzun").WithField(pgErr.ColumnName)
+		}
+	}
+
+	if m := sqliteConstraintPattern.FindStringSubmatch(err.Error()); len(m) == 3 {
+		column := m[2]
+		switch m[1] {
+		case "UNIQUE":
+			return Wrap(err, KindConflict, "bu değer zaten kullanılıyor").WithField(column)
+		case "FOREIGN KEY":
+			return Wrap(err, KindConflict, "kayıt başka kayıtlarla ilişkili olduğu için işlem yapılamadı")
+		case "NOT NULL":
+			return Wrap(err, KindValidation, "zorunlu bir alan boş bırakıldı").WithField(column)
+		case "CHECK":
+			return Wrap(err, KindValidation, "geçersiz bir değer girildi")
+		}
+	}
+
+	if IsConnectionError(err) {
+		return Unavailable("veritabanına şu anda ulaşılamıyor", err)
+	}
+
+	return Internal("veritabanı işlemi başarısız oldu", err)
+}
+
+func IsConnectionError(err error) bool {
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, sql.ErrConnDone) || errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+	var connectErr *pgconn.ConnectError
+	if errors.As(err, &connectErr) {
+		return true
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr)
+}
+
+func pgColumn(pgErr *pgconn.PgError) string {
+	if pgErr.ColumnName != "" {
+		return pgErr.ColumnName
+	}
+	if m := pgDetailKeyPattern.FindStringSubmatch(pgErr.Detail); len(m) == 2 {
+		return m[1]
+	}
+	return ""
+}
@@ -0,0 +1,82 @@
+package domainerrors
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+func TestFromDB(t *testing.T) {
+	existing := Forbidden("izin yok")
+
+	tests := []struct {
+		name   string
+		err    error
+		kind   Kind
+		field  string
+		status int
+	}{
+		{name: "nil", err: nil, kind: "", status: http.StatusOK},
+		{name: "already domain error", err: existing, kind: KindForbidden, status: http.StatusForbidden},
+		{name: "record not found", err: fmt.Errorf("sorgu: %w", gorm.ErrRecordNotFound), kind: KindNotFound, status: http.StatusNotFound},
+		{name: "gorm duplicated key", err: gorm.ErrDuplicatedKey, kind: KindConflict, status: http.StatusConflict},
+		{name: "pg unique with detail", err: &pgconn.PgError{Code: pgUniqueViolation, Detail: "Key (account)=(ali) already exists."}, kind: KindConflict, field: "account", status: http.StatusConflict},
+		{name: "pg foreign key", err: &pgconn.PgError{Code: pgForeignKeyViolation, ColumnName: "manager_id"}, kind: KindConflict, field: "manager_id", status: http.StatusConflict},
+		{name: "pg not null", err: &pgconn.PgError{Code: pgNotNullViolation, ColumnName: "name"}, kind: KindValidation, field: "name", status: http.StatusBadRequest},
+		{name: "pg check", err: &pgconn.PgError{Code: pgCheckViolation}, kind: KindValidation, status: http.StatusBadRequest},
+		{name: "pg invalid text", err: &pgconn.PgError{Code: pgInvalidTextRepr}, kind: KindValidation, status: http.StatusBadRequest},
+		{name: "pg string too long", err: &pgconn.PgError{Code: pgStringTooLong, ColumnName: "phone"}, kind: KindValidation, field: "phone", status: http.StatusBadRequest},
+		{name: "pg other", err: &pgconn.PgError{Code: "42P01"}, kind: KindInternal, status: http.StatusInternalServerError},
+		{name: "sqlite unique", err: errors.New("constraint failed: UNIQUE constraint failed: users.account (2067)"), kind: KindConflict, field: "account", status: http.StatusConflict},
+		{name: "sqlite foreign key", err: errors.New("FOREIGN KEY constraint failed"), kind: KindConflict, status: http.StatusConflict},
+		{name: "sqlite not null", err: errors.New("NOT NULL constraint failed: users.name"), kind: KindValidation, field: "name", status: http.StatusBadRequest},
+		{name: "sqlite check", err: errors.New("CHECK constraint failed: status_check"), kind: KindValidation, status: http.StatusBadRequest},
+		{name: "bad connection", err: driver.ErrBadConn, kind: KindUnavailable, status: http.StatusServiceUnavailable},
+		{name: "connection done", err: sql.ErrConnDone, kind: KindUnavailable, status: http.StatusServiceUnavailable},
+		{name: "connection refused", err: fmt.Errorf("bağlan: %w", syscall.ECONNREFUSED), kind: KindUnavailable, status: http.StatusServiceUnavailable},
+		{name: "dial error", err: &net.OpError{Op: "dial", Err: errors.New("timeout")}, kind: KindUnavailable, status: http.StatusServiceUnavailable},
+		{name: "dns error", err: &net.DNSError{Name: "db.local"}, kind: KindUnavailable, status: http.StatusServiceUnavailable},
+		{name: "read error is not unavailable", err: &net.OpError{Op: "read", Err: errors.New("reset")}, kind: KindInternal, status: http.StatusInternalServerError},
+		{name: "query timeout is not unavailable", err: context.DeadlineExceeded, kind: KindInternal, status: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := FromDB(tt.err)
+			if tt.err == nil {
+				if err != nil {
+					t.Fatalf("nil beklenirken %v döndü", err)
+				}
+				return
+			}
+			if KindOf(err) != tt.kind || FieldOf(err) != tt.field {
+				t.Fatalf("beklenen %s/%q, dönen %s/%q (%v)", tt.kind, tt.field, KindOf(err), FieldOf(err), err)
+			}
+			if HTTPStatus(err) != tt.status {
+				t.Fatalf("beklenen HTTP %d, dönen %d", tt.status, HTTPStatus(err))
+			}
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("özgün hata zincirde korunmalı: %v", err)
+			}
+		})
+	}
+}
+
+func TestIsUnavailableThroughWrapping(t *testing.T) {
+	err := WithMessage(FromDB(driver.ErrBadConn), "kullanıcılar okunamadı")
+	if !IsUnavailable(err) || HTTPStatus(err) != http.StatusServiceUnavailable {
+		t.Fatalf("sarmalanmış bağlantı hatası 503 dönmeli, dönen: %d", HTTPStatus(err))
+	}
+	if UserMessage(Internal("", errors.New("iç"))) == "" {
+		t.Fatal("iç hatalar için genel mesaj dönmeli")
+	}
+}
@@ -0,0 +1,154 @@
+package domainerrors
+
+import (
+	"errors"
+	"net/http"
+)
+
+type Kind string
+
+const (
+	KindNotFound    Kind = "not_found"
+	KindConflict    Kind = "conflict"
+	KindValidation  Kind = "validation"
+	KindForbidden   Kind = "forbidden"
+	KindUnavailable Kind = "unavailable"
+	KindInternal    Kind = "internal"
+)
+
+type Error struct {
+	Kind    Kind
+	Message string
+	Field   string
+	Err     error
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func (e *Error) WithField(field string) *Error {
+	clone := *e
+	clone.Field = field
+	return &clone
+}
+
+func New(kind Kind, message string) *Error {
+	return &Error{Kind: kind, Message: message}
+}
+
+func Wrap(err error, kind Kind, message string) *Error {
+	return &Error{Kind: kind, Message: message, Err: err}
+}
+
+func NotFound(message string) *Error {
+	return New(KindNotFound, message)
+}
+
+func Conflict(message string) *Error {
+	return New(KindConflict, message)
+}
+
+func Validation(message string) *Error {
+	return New(KindValidation, message)
+}
+
+func Forbidden(message string) *Error {
+	return New(KindForbidden, message)
+}
+
+func Unavailable(message string, err error) *Error {
+	return Wrap(err, KindUnavailable, message)
+}
+
+func Internal(message string, err error) *Error {
+	return Wrap(err, KindInternal, message)
+}
+
+func WithMessage(err error, message string) *Error {
+	var de *Error
+	if errors.As(err, &de) {
+		return &Error{Kind: de.Kind, Message: message, Field: de.Field, Err: err}
+	}
+	return Internal(message, err)
+}
+
+func KindOf(err error) Kind {
+	if err == nil {
+		return ""
+	}
+	var de *Error
+	if errors.As(err, &de) {
+		return de.Kind
+	}
+	return KindInternal
+}
+
+func FieldOf(err error) string {
+	var de *Error
+	if errors.As(err, &de) {
+		return de.Field
+	}
+	return ""
+}
+
+func IsNotFound(err error) bool   { return KindOf(err) == KindNotFound }
+func IsConflict(err error) bool   { return KindOf(err) == KindConflict }
+func IsValidation(err error) bool { return KindOf(err) == KindValidation }
+func IsForbidden(err error) bool  { return KindOf(err) == KindForbidden }
+
+func IsUnavailable(err error) bool {
+	for err != nil {
+		var de *Error
+		if !errors.As(err, &de) {
+			return false
+		}
+		if de.Kind == KindUnavailable {
+			return true
+		}
+		err = de.Err
+	}
+	return false
+}
+
+func HTTPStatus(err error) int {
+	switch KindOf(err) {
+	case "":
+		return http.StatusOK
+	case KindNotFound:
+		return http.StatusNotFound
+	case KindConflict:
+		return http.StatusConflict
+	case KindValidation:
+		return http.StatusBadRequest
+	case KindForbidden:
+		return http.StatusForbidden
+	case KindUnavailable:
+		return http.StatusServiceUnavailable
+	default:
+		if IsUnavailable(err) {
+			return http.StatusServiceUnavailable
+		}
+		return http.StatusInternalServerError
+	}
+}
+
+const internalMessage = "Beklenmeyen bir hata oluştu. Lütfen daha sonra tekrar deneyin."
+
+func UserMessage(err error) string {
+	if err == nil {
+		return ""
+	}
+	var de *Error
+	if !errors.As(err, &de) || de.Message == "" {
+		return internalMessage
+	}
+	return de.Message
+}
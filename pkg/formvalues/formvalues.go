@@ -0,0 +1,48 @@
+package formvalues
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"zatrano/pkg/validation"
+)
+
+func Int(value string) int {
+	n, _ := strconv.Atoi(strings.TrimSpace(value))
+	return n
+}
+
+func Uint(value string) uint {
+	n, _ := strconv.ParseUint(strings.TrimSpace(value), 10, 64)
+	return uint(n)
+}
+
+func Float(value string) float64 {
+	n, _ := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	return n
+}
+
+func Bool(value string) bool {
+	return value == "true"
+}
+
+func Date(value string) *time.Time {
+	return parseTime(validation.DateLayout, value)
+}
+
+func DateTime(value string) *time.Time {
+	return parseTime(validation.DateTimeLayout, value)
+}
+
+func parseTime(layout, value string) *time.Time {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+	t, err := time.ParseInLocation(layout, value, time.Local)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
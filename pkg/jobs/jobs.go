@@ -0,0 +1,148 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultMaxAttempts = 5
+	DefaultTimeout     = 5 * time.Minute
+	backoffBase        = 10 * time.Second
+	backoffMax         = time.Hour
+)
+
+var (
+	ErrUnknownJob      = errors.New("iş türü için kayıtlı bir işleyici yok")
+	ErrPayloadMismatch = errors.New("iş verisi kayıtlı türle uyuşmuyor")
+)
+
+type Handler[T any] struct {
+	Name        string
+	MaxAttempts int
+	Timeout     time.Duration
+	Handle      func(ctx context.Context, payload T) error
+}
+
+type Kind[T any] struct {
+	name string
+}
+
+func (k Kind[T]) Name() string {
+	return k.name
+}
+
+func (k Kind[T]) New(payload T) (Envelope, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return Envelope{}, fmt.Errorf("%s iş verisi kodlanamadı: %w", k.name, err)
+	}
+	definition, _ := lookup(k.name)
+	return Envelope{Name: k.name, Payload: string(data), MaxAttempts: definition.maxAttempts}, nil
+}
+
+type Envelope struct {
+	Name        string
+	Payload     string
+	MaxAttempts int
+	RunAt       time.Time
+}
+
+func (e Envelope) At(runAt time.Time) Envelope {
+	e.RunAt = runAt
+	return e
+}
+
+type definition struct {
+	maxAttempts int
+	timeout     time.Duration
+	run         func(ctx context.Context, payload []byte) error
+}
+
+var (
+	mu       sync.RWMutex
+	handlers = make(map[string]definition)
+)
+
+func Register[T any](handler Handler[T]) Kind[T] {
+	if handler.Name == "" || handler.Handle == nil {
+		panic("jobs: iş adı ve işleyici zorunludur")
+	}
+	if handler.MaxAttempts <= 0 {
+		handler.MaxAttempts = DefaultMaxAttempts
+	}
+	if handler.Timeout <= 0 {
+		handler.Timeout = DefaultTimeout
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if _, exists := handlers[handler.Name]; exists {
+		panic("jobs: " + handler.Name + " iş türü zaten kayıtlı")
+	}
+	handlers[handler.Name] = definition{
+		maxAttempts: handler.MaxAttempts,
+		timeout:     handler.Timeout,
+		run: func(ctx context.Context, data []byte) error {
+			var payload T
+			if err := json.Unmarshal(data, &payload); err != nil {
+				return fmt.Errorf("%w: %v", ErrPayloadMismatch, err)
+			}
+			return handler.Handle(ctx, payload)
+		},
+	}
+	return Kind[T]{name: handler.Name}
+}
+
+func Names() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	names := make([]string, 0, len(handlers))
+	for name := range handlers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func Run(ctx context.Context, name, payload string) (err error) {
+	definition, ok := lookup(name)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownJob, name)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, definition.timeout)
+	defer cancel()
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("işleyici panik yaptı: %v", recovered)
+		}
+	}()
+	return definition.run(ctx, []byte(payload))
+}
+
+func Permanent(err error) bool {
+	return errors.Is(err, ErrUnknownJob) || errors.Is(err, ErrPayloadMismatch)
+}
+
+func Backoff(attempt int) time.Duration {
+	delay := backoffBase
+	for i := 1; i < attempt && delay < backoffMax; i++ {
+		delay *= 2
+	}
+	delay = min(delay, backoffMax)
+	return delay/2 + rand.N(delay/2+1)
+}
+
+func lookup(name string) (definition, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	definition, ok := handlers[name]
+	return definition, ok
+}
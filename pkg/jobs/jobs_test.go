@@ -0,0 +1,115 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempt  int
+		min, max time.Duration
+	}{
+		{0, 5 * time.Second, 10 * time.Second},
+		{1, 5 * time.Second, 10 * time.Second},
+		{2, 10 * time.Second, 20 * time.Second},
+		{3, 20 * time.Second, 40 * time.Second},
+		{10, 30 * time.Minute, time.Hour},
+		{1000, 30 * time.Minute, time.Hour},
+	}
+	for _, tt := range tests {
+		for range 50 {
+			if got := Backoff(tt.attempt); got < tt.min || got > tt.max {
+				t.Fatalf("Backoff(%d) = %s, beklenen aralık [%s, %s]", tt.attempt, got, tt.min, tt.max)
+			}
+		}
+	}
+}
+
+type testPayload struct {
+	ID int `json:"id"`
+}
+
+func TestRun(t *testing.T) {
+	var got testPayload
+	kind := Register(Handler[testPayload]{
+		Name: "test.run",
+		Handle: func(ctx context.Context, payload testPayload) error {
+			if payload.ID < 0 {
+				panic("negatif kimlik")
+			}
+			got = payload
+			return nil
+		},
+	})
+
+	envelope, err := kind.New(testPayload{ID: 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if envelope.Name != "test.run" || envelope.MaxAttempts != DefaultMaxAttempts {
+		t.Fatalf("beklenmeyen zarf: %+v", envelope)
+	}
+
+	tests := []struct {
+		name, job, payload string
+		permanent          bool
+		errContains        string
+	}{
+		{"başarılı", "test.run", envelope.Payload, false, ""},
+		{"bilinmeyen iş", "test.yok", "{}", true, "test.yok"},
+		{"uyumsuz veri", "test.run", `{"id":"yedi"}`, true, ""},
+		{"panik", "test.run", `{"id":-1}`, false, "panik"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Run(context.Background(), tt.job, tt.payload)
+			if tt.errContains == "" && !tt.permanent {
+				if err != nil {
+					t.Fatalf("beklenmeyen hata: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("hata bekleniyordu")
+			}
+			if Permanent(err) != tt.permanent {
+				t.Fatalf("Permanent(%v) = %v", err, !tt.permanent)
+			}
+			if !strings.Contains(err.Error(), tt.errContains) {
+				t.Fatalf("hata %q içermeli: %v", tt.errContains, err)
+			}
+		})
+	}
+	if got.ID != 7 {
+		t.Fatalf("işleyici veriyi almadı: %+v", got)
+	}
+}
+
+func TestRunAppliesTimeout(t *testing.T) {
+	Register(Handler[testPayload]{
+		Name:    "test.timeout",
+		Timeout: 10 * time.Millisecond,
+		Handle: func(ctx context.Context, _ testPayload) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	})
+	if err := Run(context.Background(), "test.timeout", "{}"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("zaman aşımı bekleniyordu: %v", err)
+	}
+}
+
+func TestRegisterRejectsDuplicate(t *testing.T) {
+	handler := Handler[testPayload]{Name: "test.duplicate", Handle: func(context.Context, testPayload) error { return nil }}
+	Register(handler)
+	defer func() {
+		if recover() == nil {
+			t.Fatal("aynı ad ikinci kez kaydedilememeli")
+		}
+	}()
+	Register(handler)
+}
@@ -0,0 +1,34 @@
+package ownership
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const Column = "manager_id"
+
+const contextManagerIDKey = "manager_id"
+
+func WithManager(ctx context.Context, managerID uint) context.Context {
+	return context.WithValue(ctx, contextManagerIDKey, managerID)
+}
+
+func ManagerID(ctx context.Context) (uint, bool) {
+	if ctx == nil {
+		return 0, false
+	}
+	managerID, ok := ctx.Value(contextManagerIDKey).(uint)
+	return managerID, ok && managerID != 0
+}
+
+func Scope(ctx context.Context) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		managerID, ok := ManagerID(ctx)
+		if !ok {
+			return db
+		}
+		return db.Where(clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: Column}, Value: managerID})
+	}
+}
@@ -0,0 +1,160 @@
+package queryparams
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"zatrano/pkg/env"
+	"zatrano/pkg/logs"
+)
+
+const (
+	CursorNext = "next"
+	CursorPrev = "prev"
+)
+
+var ErrInvalidCursor = errors.New("geçersiz sayfalama imleci")
+
+type Cursor struct {
+	SortBy    string `json:"s"`
+	OrderBy   string `json:"o"`
+	Kind      string `json:"k"`
+	Value     string `json:"v"`
+	ID        uint   `json:"i"`
+	Direction string `json:"d"`
+}
+
+type CursorMeta struct {
+	PerPage        int    `json:"per_page"`
+	NextCursor     string `json:"next_cursor,omitempty"`
+	PrevCursor     string `json:"prev_cursor,omitempty"`
+	HasNext        bool   `json:"has_next"`
+	HasPrev        bool   `json:"has_prev"`
+	TotalItems     int64  `json:"total_items"`
+	TotalEstimated bool   `json:"total_estimated"`
+}
+
+var (
+	cursorSecretOnce sync.Once
+	cursorSecret     []byte
+)
+
+func secret() []byte {
+	cursorSecretOnce.Do(func() {
+		if key := env.GetEnvWithDefault("APP_KEY", ""); key != "" {
+			cursorSecret = []byte(key)
+			return
+		}
+		cursorSecret = make([]byte, 32)
+		if _, err := rand.Read(cursorSecret); err != nil {
+			panic("sayfalama imleci anahtarı üretilemedi: " + err.Error())
+		}
+		if logs.SLog != nil {
+			logs.SLog.Warn("APP_KEY tanımlı değil, sayfalama imleçleri için geçici anahtar üretildi; imleçler yeniden başlatmada geçersiz olur.")
+		}
+	})
+	return cursorSecret
+}
+
+func NewCursor(sortBy, orderBy string, value interface{}, id uint, direction string) (Cursor, error) {
+	kind, raw, err := encodeCursorValue(value)
+	if err != nil {
+		return Cursor{}, err
+	}
+	return Cursor{
+		SortBy:    sortBy,
+		OrderBy:   orderBy,
+		Kind:      kind,
+		Value:     raw,
+		ID:        id,
+		Direction: direction,
+	}, nil
+}
+
+func (c Cursor) Encode() string {
+	payload, _ := json.Marshal(c)
+	mac := hmac.New(sha256.New, secret())
+	mac.Write(payload)
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func DecodeCursor(token string) (*Cursor, error) {
+	payloadPart, sigPart, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(payloadPart)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(sigPart)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	mac := hmac.New(sha256.New, secret())
+	mac.Write(payload)
+	if !hmac.Equal(sig, mac.Sum(nil)) {
+		return nil, ErrInvalidCursor
+	}
+
+	var c Cursor
+	if err := json.Unmarshal(payload, &c); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if c.Direction != CursorNext && c.Direction != CursorPrev {
+		return nil, ErrInvalidCursor
+	}
+	return &c, nil
+}
+
+func (c Cursor) SortValue() (interface{}, error) {
+	switch c.Kind {
+	case "time":
+		return time.Parse(time.RFC3339Nano, c.Value)
+	case "int":
+		return strconv.ParseInt(c.Value, 10, 64)
+	case "uint":
+		return strconv.ParseUint(c.Value, 10, 64)
+	case "float":
+		return strconv.ParseFloat(c.Value, 64)
+	case "bool":
+		return strconv.ParseBool(c.Value)
+	case "string":
+		return c.Value, nil
+	default:
+		return nil, ErrInvalidCursor
+	}
+}
+
+func encodeCursorValue(value interface{}) (string, string, error) {
+	if t, ok := value.(time.Time); ok {
+		return "time", t.UTC().Format(time.RFC3339Nano), nil
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.String:
+		return "string", v.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return "int", strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "uint", strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return "float", strconv.FormatFloat(v.Float(), 'g', -1, 64), nil
+	case reflect.Bool:
+		return "bool", strconv.FormatBool(v.Bool()), nil
+	default:
+		return "", "", fmt.Errorf("sayfalama imleci için desteklenmeyen değer tipi: %T", value)
+	}
+}
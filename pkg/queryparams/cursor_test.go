@@ -0,0 +1,105 @@
+package queryparams
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	createdAt := time.Date(2024, 3, 1, 10, 30, 0, 123, time.FixedZone("TRT", 3*60*60))
+
+	tests := []struct {
+		name  string
+		value interface{}
+		kind  string
+		want  interface{}
+	}{
+		{name: "time", value: createdAt, kind: "time", want: createdAt.UTC()},
+		{name: "int", value: -42, kind: "int", want: int64(-42)},
+		{name: "uint", value: uint(7), kind: "uint", want: uint64(7)},
+		{name: "float", value: 1.5, kind: "float", want: 1.5},
+		{name: "bool", value: true, kind: "bool", want: true},
+		{name: "string", value: "Işık.Çiçek", kind: "string", want: "Işık.Çiçek"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cursor, err := NewCursor("name", "desc", tt.value, 9, CursorPrev)
+			if err != nil {
+				t.Fatal(err)
+			}
+			decoded, err := DecodeCursor(cursor.Encode())
+			if err != nil {
+				t.Fatalf("imleç çözülemedi: %v", err)
+			}
+			if *decoded != cursor || decoded.Kind != tt.kind {
+				t.Fatalf("beklenen %+v, dönen %+v", cursor, *decoded)
+			}
+			value, err := decoded.SortValue()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, ok := value.(time.Time); ok {
+				if !got.Equal(tt.want.(time.Time)) {
+					t.Fatalf("beklenen %v, dönen %v", tt.want, got)
+				}
+				return
+			}
+			if value != tt.want {
+				t.Fatalf("beklenen %#v, dönen %#v", tt.want, value)
+			}
+		})
+	}
+}
+
+func TestNewCursorRejectsUnsupportedValue(t *testing.T) {
+	if _, err := NewCursor("id", "asc", []int{1}, 1, CursorNext); err == nil {
+		t.Fatal("desteklenmeyen değer tipi reddedilmeli")
+	}
+}
+
+func TestDecodeCursorRejectsTampering(t *testing.T) {
+	cursor, err := NewCursor("id", "asc", uint(10), 10, CursorNext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := cursor.Encode()
+	payloadPart, sigPart, _ := strings.Cut(token, ".")
+
+	forged := `{"s":"id","o":"asc","k":"uint","v":"1","i":1,"d":"next"}`
+	otherKey := hmac.New(sha256.New, []byte("başka anahtar"))
+	otherKey.Write([]byte(forged))
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "empty", token: ""},
+		{name: "no signature", token: payloadPart},
+		{name: "payload changed", token: base64.RawURLEncoding.EncodeToString([]byte(forged)) + "." + sigPart},
+		{name: "signature changed", token: payloadPart + "." + base64.RawURLEncoding.EncodeToString([]byte("imza"))},
+		{name: "signed with another key", token: base64.RawURLEncoding.EncodeToString([]byte(forged)) + "." + base64.RawURLEncoding.EncodeToString(otherKey.Sum(nil))},
+		{name: "bad payload encoding", token: "!!!." + sigPart},
+		{name: "bad signature encoding", token: payloadPart + ".!!!"},
+		{name: "invalid direction", token: Cursor{SortBy: "id", Kind: "uint", Value: "1", ID: 1, Direction: "sideways"}.Encode()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DecodeCursor(tt.token); !errors.Is(err, ErrInvalidCursor) {
+				t.Fatalf("ErrInvalidCursor bekleniyordu, dönen: %v", err)
+			}
+		})
+	}
+}
+
+func TestSortValueRejectsUnknownKind(t *testing.T) {
+	if _, err := (Cursor{Kind: "map", Value: "x"}).SortValue(); !errors.Is(err, ErrInvalidCursor) {
+		t.Fatalf("bilinmeyen değer tipi reddedilmeli, dönen: %v", err)
+	}
+}
@@ -10,6 +10,11 @@ const (
 	MaxPerPage     = 100
 )
 
+const (
+	OffsetMode = "offset"
+	CursorMode = "cursor"
+)
+
 type ListParams struct {
 	Name string `query:"name"`
 
@@ -18,6 +23,10 @@ type ListParams struct {
 
 	Page    int `query:"page"`
 	PerPage int `query:"perPage"`
+
+	Mode   string `query:"mode"`
+	Cursor string `query:"cursor"`
+	Exact  bool   `query:"exact"`
 }
 
 type PaginationMeta struct {
@@ -28,8 +37,13 @@ type PaginationMeta struct {
 }
 
 type PaginatedResult struct {
-	Data interface{}    `json:"data"`
-	Meta PaginationMeta `json:"meta"`
+	Data   interface{}    `json:"data"`
+	Meta   PaginationMeta `json:"meta"`
+	Cursor *CursorMeta    `json:"cursor,omitempty"`
+}
+
+func (p *ListParams) IsCursorMode() bool {
+	return p.Mode == CursorMode || p.Cursor != ""
 }
 
 func (p *ListParams) CalculateOffset() int {
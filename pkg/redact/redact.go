@@ -0,0 +1,10 @@
+package redact
+
+const Value = "[gizlendi]"
+
+func String(value string) string {
+	if value == "" {
+		return ""
+	}
+	return Value
+}
@@ -2,7 +2,9 @@ package renderer
 
 import (
 	"net/http"
+	"strings"
 	"zatrano/pkg/flashmessages"
+	"zatrano/pkg/validation"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/log"
@@ -14,6 +16,12 @@ const (
 	FlashSuccessKeyView = "Success"
 	FlashErrorKeyView   = "Error"
 	FormDataKey         = "FormData"
+	FieldErrorsKey      = "FieldErrors"
+	UnreadNotifications = "UnreadNotificationCount"
+	CurrentOrganization = "CurrentOrganization"
+	Organizations       = "Organizations"
+	SuperAdmin          = "IsSuperAdmin"
+	OwnAgentsOnly       = "OwnAgentsOnly"
 )
 
 func prepareRenderData(c *fiber.Ctx, data fiber.Map) fiber.Map {
@@ -38,6 +46,14 @@ func prepareRenderData(c *fiber.Ctx, data fiber.Map) fiber.Map {
 		}
 	}
 
+	renderData[FieldErrorsKey] = validation.Errors{}
+
+	for _, key := range []string{UnreadNotifications, CurrentOrganization, Organizations, SuperAdmin, OwnAgentsOnly} {
+		if value := c.Locals(key); value != nil {
+			renderData[key] = value
+		}
+	}
+
 	for key, value := range data {
 		renderData[key] = value
 	}
@@ -85,3 +101,7 @@ func Render(c *fiber.Ctx, template string, layout string, data fiber.Map, status
 		return c.Status(status).Render(template, finalData, layout)
 	}
 }
+
+func WantsJSON(c *fiber.Ctx) bool {
+	return strings.Contains(c.Get(fiber.HeaderAccept), fiber.MIMEApplicationJSON)
+}
@@ -0,0 +1,174 @@
+package replica
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"zatrano/pkg/logs"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const contextForcePrimaryKey = "force_primary"
+
+const pingTimeout = 2 * time.Second
+
+var stickiness atomic.Int64
+
+type Pool struct {
+	Name    string
+	DB      *gorm.DB
+	healthy atomic.Bool
+}
+
+func (p *Pool) Healthy() bool {
+	return p.healthy.Load()
+}
+
+var (
+	mu      sync.RWMutex
+	pools   []*Pool
+	next    atomic.Uint64
+	stopped chan struct{}
+)
+
+func ForcePrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, contextForcePrimaryKey, true)
+}
+
+func PrimaryForced(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	forced, _ := ctx.Value(contextForcePrimaryKey).(bool)
+	return forced
+}
+
+func SetStickiness(duration time.Duration) {
+	stickiness.Store(int64(duration))
+}
+
+func Stickiness() time.Duration {
+	return time.Duration(stickiness.Load())
+}
+
+func Register(name string, db *gorm.DB) *Pool {
+	pool := &Pool{Name: name, DB: db}
+	mu.Lock()
+	pools = append(pools, pool)
+	mu.Unlock()
+
+	if err := ping(db); err != nil {
+		logs.Log.Warn("Okuma replikasına başlangıçta ulaşılamadı, erişilebilir olana kadar birincil veritabanı kullanılacak", zap.String("replica", name), zap.Error(err))
+	} else {
+		pool.healthy.Store(true)
+	}
+	return pool
+}
+
+func Enabled() bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	return len(pools) > 0
+}
+
+func Pools() []*Pool {
+	mu.RLock()
+	defer mu.RUnlock()
+	return append([]*Pool(nil), pools...)
+}
+
+func Reader(ctx context.Context, primary *gorm.DB) *gorm.DB {
+	if PrimaryForced(ctx) {
+		return primary
+	}
+
+	mu.RLock()
+	defer mu.RUnlock()
+	if len(pools) == 0 {
+		return primary
+	}
+
+	start := next.Add(1)
+	for i := 0; i < len(pools); i++ {
+		pool := pools[(start+uint64(i))%uint64(len(pools))]
+		if pool.Healthy() {
+			return pool.DB
+		}
+	}
+	return primary
+}
+
+func CheckAll() {
+	for _, pool := range Pools() {
+		check(pool)
+	}
+}
+
+func StartHealthCheck(interval time.Duration) {
+	mu.Lock()
+	if stopped != nil || len(pools) == 0 {
+		mu.Unlock()
+		return
+	}
+	stopped = make(chan struct{})
+	done := stopped
+	mu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				CheckAll()
+			case <-done:
+				return
+			}
+		}
+	}()
+}
+
+func Close() {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if stopped != nil {
+		close(stopped)
+		stopped = nil
+	}
+	for _, pool := range pools {
+		if sqlDB, err := pool.DB.DB(); err == nil {
+			if err := sqlDB.Close(); err != nil {
+				logs.Log.Warn("Okuma replikası kapatılamadı", zap.String("replica", pool.Name), zap.Error(err))
+			}
+		}
+	}
+	pools = nil
+}
+
+func check(pool *Pool) {
+	err := ping(pool.DB)
+	healthy := err == nil
+	if pool.healthy.Swap(healthy) == healthy {
+		return
+	}
+	if healthy {
+		logs.Log.Info("Okuma replikası kullanılabilir durumda", zap.String("replica", pool.Name))
+	} else {
+		logs.Log.Warn("Okuma replikasına ulaşılamıyor, okumalar birincil veritabanına yönlendirilecek", zap.String("replica", pool.Name), zap.Error(err))
+	}
+}
+
+func ping(db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), pingTimeout)
+	defer cancel()
+	return sqlDB.PingContext(ctx)
+}
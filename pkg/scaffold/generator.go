@@ -0,0 +1,201 @@
+package scaffold
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+)
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+var templateFuncs = template.FuncMap{
+	"dict": func(values ...interface{}) map[string]interface{} {
+		dict := make(map[string]interface{}, len(values)/2)
+		for i := 0; i+1 < len(values); i += 2 {
+			if key, ok := values[i].(string); ok {
+				dict[key] = values[i+1]
+			}
+		}
+		return dict
+	},
+}
+
+type target struct {
+	template string
+	path     string
+}
+
+func (r *Resource) targets() []target {
+	return []target{
+		{"model.go.tmpl", filepath.Join("models", r.FileName+".go")},
+		{"migration.go.tmpl", filepath.Join("database", "migrations", r.Table+".go")},
+		{"repository.go.tmpl", filepath.Join("repositories", r.FileName+"_repository.go")},
+		{"service.go.tmpl", filepath.Join("services", r.FileName+"_service.go")},
+		{"handler.go.tmpl", filepath.Join("handlers", "dashboard", "dashboard_"+r.FileName+"_handler.go")},
+		{"list.html.tmpl", filepath.Join("views", filepath.FromSlash(r.ViewDir), "list.html")},
+		{"create.html.tmpl", filepath.Join("views", filepath.FromSlash(r.ViewDir), "create.html")},
+		{"update.html.tmpl", filepath.Join("views", filepath.FromSlash(r.ViewDir), "update.html")},
+	}
+}
+
+func Generate(res *Resource, opts Options) ([]string, error) {
+	root := opts.Root
+	if root == "" {
+		root = "."
+	}
+
+	targets := res.targets()
+	if !opts.Force {
+		var existing []string
+		for _, t := range targets {
+			if _, err := os.Stat(filepath.Join(root, t.path)); err == nil {
+				existing = append(existing, t.path)
+			}
+		}
+		if len(existing) > 0 {
+			return nil, fmt.Errorf("şu dosyalar zaten mevcut, üzerine yazmak için -force kullanın:\n  %s", strings.Join(existing, "\n  "))
+		}
+	}
+
+	tmpl, err := template.New("scaffold").Delims("[[", "]]").Funcs(templateFuncs).ParseFS(templateFS, "templates/*.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("şablonlar yüklenemedi: %w", err)
+	}
+
+	rendered := make(map[string][]byte, len(targets))
+	for _, t := range targets {
+		var buf bytes.Buffer
+		if err := tmpl.ExecuteTemplate(&buf, t.template, res); err != nil {
+			return nil, fmt.Errorf("%s oluşturulamadı: %w", t.path, err)
+		}
+		content := normalizeNewlines(buf.Bytes())
+		if strings.HasSuffix(t.path, ".go") {
+			formatted, err := format.Source(content)
+			if err != nil {
+				return nil, fmt.Errorf("%s biçimlendirilemedi: %w", t.path, err)
+			}
+			content = formatted
+		}
+		rendered[t.path] = toCRLF(content)
+	}
+
+	var written []string
+	for _, t := range targets {
+		fullPath := filepath.Join(root, t.path)
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0o755); err != nil {
+			return written, err
+		}
+		if err := os.WriteFile(fullPath, rendered[t.path], 0o644); err != nil {
+			return written, err
+		}
+		written = append(written, t.path)
+	}
+
+	for _, register := range []func(string, *Resource) (string, bool, error){registerRoutes, registerMigration} {
+		path, changed, err := register(root, res)
+		if err != nil {
+			return written, err
+		}
+		if changed {
+			written = append(written, path)
+		}
+	}
+
+	return written, nil
+}
+
+func registerRoutes(root string, res *Resource) (string, bool, error) {
+	path := filepath.Join("routes", "dashboard.go")
+	handlerVar := res.VarName + "Handler"
+	block := fmt.Sprintf(`
+	%[1]s := handlers.New%[2]sHandler()
+	dashboardGroup.Get("%[3]s", %[1]s.List%[4]s)
+	dashboardGroup.Get("%[3]s/create", %[1]s.ShowCreate%[2]s)
+	dashboardGroup.Post("%[3]s/create", %[1]s.Create%[2]s)
+	dashboardGroup.Get("%[3]s/update/:id", %[1]s.ShowUpdate%[2]s)
+	dashboardGroup.Post("%[3]s/update/:id", %[1]s.Update%[2]s)
+	dashboardGroup.Delete("%[3]s/delete/:id", %[1]s.Delete%[2]s)
+`, handlerVar, res.Name, strings.TrimPrefix(res.RoutePath, "/dashboard"), res.PluralName)
+
+	return insertIntoFunc(root, path, "func registerDashboardRoutes(", "handlers.New"+res.Name+"Handler()", block)
+}
+
+func registerMigration(root string, res *Resource) (string, bool, error) {
+	path := filepath.Join("database", "migrations", "registry.go")
+	id := time.Now().UTC().Format("20060102150405") + "_create_" + res.Table
+	block := fmt.Sprintf("\t\t{ID: \"%s\", Up: Migrate%sTable, Down: Drop%sTable},", id, res.PluralName, res.PluralName)
+	return insertBefore(root, path, "func All() []Migration {", "\t}\n}", "Up: Migrate"+res.PluralName+"Table,", block)
+}
+
+func insertIntoFunc(root, path, funcSignature, marker, block string) (string, bool, error) {
+	source, err := readSource(root, path)
+	if err != nil {
+		return path, false, err
+	}
+	if strings.Contains(source, marker) {
+		return path, false, nil
+	}
+
+	start := strings.Index(source, funcSignature)
+	if start < 0 {
+		return path, false, fmt.Errorf("%s içinde %s bulunamadı", path, funcSignature)
+	}
+	end := strings.Index(source[start:], "\n}\n")
+	if end < 0 {
+		return path, false, fmt.Errorf("%s içinde %s fonksiyonunun sonu bulunamadı", path, funcSignature)
+	}
+	end += start + 1
+
+	updated := source[:end] + block + source[end:]
+	return path, true, writeSource(root, path, updated)
+}
+
+func insertBefore(root, path, funcSignature, anchor, marker, block string) (string, bool, error) {
+	source, err := readSource(root, path)
+	if err != nil {
+		return path, false, err
+	}
+	if strings.Contains(source, marker) {
+		return path, false, nil
+	}
+
+	start := strings.Index(source, funcSignature)
+	if start < 0 {
+		return path, false, fmt.Errorf("%s içinde %s bulunamadı", path, funcSignature)
+	}
+	pos := strings.Index(source[start:], anchor)
+	if pos < 0 {
+		return path, false, fmt.Errorf("%s içinde migrasyon listesi sonu bulunamadı", path)
+	}
+	pos += start
+
+	updated := source[:pos] + strings.TrimPrefix(block, "\n") + "\n" + source[pos:]
+	return path, true, writeSource(root, path, updated)
+}
+
+func readSource(root, path string) (string, error) {
+	content, err := os.ReadFile(filepath.Join(root, path))
+	if err != nil {
+		return "", err
+	}
+	return string(normalizeNewlines(content)), nil
+}
+
+func writeSource(root, path, source string) error {
+	return os.WriteFile(filepath.Join(root, path), toCRLF([]byte(source)), 0o644)
+}
+
+func normalizeNewlines(content []byte) []byte {
+	return bytes.ReplaceAll(content, []byte("\r\n"), []byte("\n"))
+}
+
+func toCRLF(content []byte) []byte {
+	return bytes.ReplaceAll(normalizeNewlines(content), []byte("\n"), []byte("\r\n"))
+}
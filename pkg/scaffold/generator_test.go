@@ -0,0 +1,199 @@
+package scaffold
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+const (
+	testRoutes   = "package routes\r\n\r\nfunc registerDashboardRoutes(app *fiber.App) {\r\n\tdashboardGroup := app.Group(\"/dashboard\")\r\n}\r\n"
+	testRegistry = "package migrations\r\n\r\nfunc All() []Migration {\r\n\treturn []Migration{\r\n\t\t{ID: \"0001_create_users\", Up: MigrateUsersTable, Down: DropUsersTable},\r\n\t}\r\n}\r\n"
+)
+
+func TestParseResource(t *testing.T) {
+	res, err := ParseResource("product_category", []string{"name:string", "price:float:required:label=Fiyat", "sku:string:unique", "active:bool:required"}, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Resource{
+		Name:        "ProductCategory",
+		PluralName:  "ProductCategories",
+		VarName:     "productCategory",
+		FileName:    "product_category",
+		Table:       "product_categories",
+		RoutePath:   "/dashboard/product-categories",
+		ViewDir:     "dashboard/product_categories",
+		Label:       "Product Category",
+		PluralLabel: "Product Categories",
+	}
+	got := *res
+	got.Fields = nil
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("beklenen %+v, dönen %+v", want, got)
+	}
+
+	fields := []struct {
+		goName   string
+		label    string
+		required bool
+		unique   bool
+		validate string
+	}{
+		{goName: "Name", label: "Name", required: true, validate: "required,max=255"},
+		{goName: "Price", label: "Fiyat", required: true, validate: "required,number"},
+		{goName: "SKU", label: "SKU", required: true, unique: true, validate: "required,max=255"},
+		{goName: "Active", label: "Active", validate: ""},
+	}
+	for i, f := range fields {
+		field := res.Fields[i]
+		if field.GoName != f.goName || field.Label != f.label || field.Required != f.required || field.Unique != f.unique || field.Validate() != f.validate {
+			t.Errorf("%d. alan beklenmeyen: %+v (validate %q)", i, field, field.Validate())
+		}
+	}
+}
+
+func TestParseResourceErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		res   string
+		specs []string
+	}{
+		{name: "bad resource name", res: "1product", specs: []string{"name:string"}},
+		{name: "no fields", res: "product"},
+		{name: "missing type", res: "product", specs: []string{"name"}},
+		{name: "unsupported type", res: "product", specs: []string{"name:json"}},
+		{name: "reserved column", res: "product", specs: []string{"version:int"}},
+		{name: "duplicate field", res: "product", specs: []string{"name:string", "Name:text"}},
+		{name: "unknown modifier", res: "product", specs: []string{"name:string:primary"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseResource(tt.res, tt.specs, Options{}); err == nil {
+				t.Fatal("hata bekleniyordu")
+			}
+		})
+	}
+}
+
+func TestFieldValidate(t *testing.T) {
+	tests := []struct {
+		field Field
+		want  string
+	}{
+		{field: Field{Type: TypeString, Required: true}, want: "required,max=255"},
+		{field: Field{Type: TypeText}, want: ""},
+		{field: Field{Type: TypeInt}, want: "omitempty,integer"},
+		{field: Field{Type: TypeUint, Required: true}, want: "required,integer"},
+		{field: Field{Type: TypeFloat}, want: "omitempty,number"},
+		{field: Field{Type: TypeBool}, want: ""},
+		{field: Field{Type: TypeDate, Required: true}, want: "required,date"},
+		{field: Field{Type: TypeDateTime}, want: "omitempty,datetime"},
+	}
+	for _, tt := range tests {
+		if got := tt.field.Validate(); got != tt.want {
+			t.Errorf("%s (required=%v) = %q, beklenen %q", tt.field.Type, tt.field.Required, got, tt.want)
+		}
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, root, "routes/dashboard.go", testRoutes)
+	writeTestFile(t, root, "database/migrations/registry.go", testRegistry)
+
+	res, err := ParseResource("product", []string{"name:string", "stock:int", "released_on:date"}, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	written, err := Generate(res, Options{Root: root})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantFiles := []string{
+		"models/product.go",
+		"database/migrations/products.go",
+		"repositories/product_repository.go",
+		"services/product_service.go",
+		"handlers/dashboard/dashboard_product_handler.go",
+		"views/dashboard/products/list.html",
+		"views/dashboard/products/create.html",
+		"views/dashboard/products/update.html",
+		"routes/dashboard.go",
+		"database/migrations/registry.go",
+	}
+	if len(written) != len(wantFiles) {
+		t.Fatalf("beklenen dosyalar %v, yazılan %v", wantFiles, written)
+	}
+	for i, path := range wantFiles {
+		if filepath.ToSlash(written[i]) != path {
+			t.Errorf("%d. dosya %s, beklenen %s", i, written[i], path)
+		}
+		content := readTestFile(t, root, path)
+		if strings.Contains(strings.ReplaceAll(content, "\r\n", ""), "\n") {
+			t.Errorf("%s CRLF satır sonu kullanmalı", path)
+		}
+	}
+
+	handler := readTestFile(t, root, "handlers/dashboard/dashboard_product_handler.go")
+	for _, part := range []string{
+		"validation.MustParse(ProductRequest{})",
+		"`form:\"stock\" validate:\"omitempty,integer\" label:\"Stock\"`",
+		"`form:\"released_on\" validate:\"omitempty,date\" label:\"Released On\"`",
+	} {
+		if !strings.Contains(handler, part) {
+			t.Errorf("handler içinde %q bekleniyordu", part)
+		}
+	}
+
+	routes := readTestFile(t, root, "routes/dashboard.go")
+	if !strings.Contains(routes, "productHandler := handlers.NewProductHandler()") || !strings.Contains(routes, `dashboardGroup.Delete("/products/delete/:id", productHandler.DeleteProduct)`) {
+		t.Errorf("rotalar eklenmedi:\n%s", routes)
+	}
+
+	registry := readTestFile(t, root, "database/migrations/registry.go")
+	entry := regexp.MustCompile(`\{ID: "\d{14}_create_products", Up: MigrateProductsTable, Down: DropProductsTable\},\r\n\t\}\r\n\}`)
+	if !entry.MatchString(registry) {
+		t.Errorf("migrasyon listenin sonuna eklenmedi:\n%s", registry)
+	}
+
+	if _, err := Generate(res, Options{Root: root}); err == nil || !strings.Contains(err.Error(), "-force") {
+		t.Fatalf("mevcut dosyalar için -force istenmeliydi, dönen: %v", err)
+	}
+
+	written, err = Generate(res, Options{Root: root, Force: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(written) != len(wantFiles)-2 {
+		t.Errorf("-force ile rota ve migrasyon tekrar eklenmemeli, yazılan: %v", written)
+	}
+	if strings.Count(readTestFile(t, root, "database/migrations/registry.go"), "MigrateProductsTable") != 1 {
+		t.Error("migrasyon kaydı tekrarlandı")
+	}
+}
+
+func writeTestFile(t *testing.T, root, path, content string) {
+	t.Helper()
+	full := filepath.Join(root, filepath.FromSlash(path))
+	if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(full, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readTestFile(t *testing.T, root, path string) string {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(path)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(content)
+}
@@ -0,0 +1,397 @@
+package scaffold
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"gorm.io/gorm/schema"
+)
+
+const (
+	TypeString   = "string"
+	TypeText     = "text"
+	TypeInt      = "int"
+	TypeUint     = "uint"
+	TypeFloat    = "float"
+	TypeBool     = "bool"
+	TypeDate     = "date"
+	TypeDateTime = "datetime"
+)
+
+var supportedTypes = []string{TypeString, TypeText, TypeInt, TypeUint, TypeFloat, TypeBool, TypeDate, TypeDateTime}
+
+var (
+	identifierPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+	reservedColumns   = map[string]bool{
+		"id": true, "created_at": true, "updated_at": true, "deleted_at": true,
+		"created_by": true, "updated_by": true, "deleted_by": true, "version": true,
+		"organization_id": true,
+	}
+	commonInitialisms = map[string]string{"id": "ID", "url": "URL", "ip": "IP", "api": "API", "html": "HTML", "json": "JSON", "sku": "SKU"}
+	namer             = schema.NamingStrategy{}
+)
+
+type Field struct {
+	GoName   string
+	Column   string
+	Label    string
+	Type     string
+	Required bool
+	Unique   bool
+	Index    bool
+}
+
+type Resource struct {
+	Name        string
+	PluralName  string
+	VarName     string
+	FileName    string
+	Table       string
+	RoutePath   string
+	ViewDir     string
+	Label       string
+	PluralLabel string
+	Fields      []Field
+}
+
+type Options struct {
+	Root        string
+	Force       bool
+	Label       string
+	PluralLabel string
+}
+
+func ParseResource(name string, specs []string, opts Options) (*Resource, error) {
+	if !identifierPattern.MatchString(name) {
+		return nil, fmt.Errorf("geçersiz kaynak adı: %q", name)
+	}
+	if len(specs) == 0 {
+		return nil, fmt.Errorf("en az bir alan tanımlanmalıdır (ör. name:string)")
+	}
+
+	goName := toGoName(name)
+	table := namer.TableName(goName)
+	res := &Resource{
+		Name:        goName,
+		PluralName:  toGoName(table),
+		VarName:     lowerFirst(goName),
+		FileName:    namer.ColumnName("", goName),
+		Table:       table,
+		RoutePath:   "/dashboard/" + strings.ReplaceAll(table, "_", "-"),
+		ViewDir:     "dashboard/" + table,
+		Label:       opts.Label,
+		PluralLabel: opts.PluralLabel,
+	}
+	if res.Label == "" {
+		res.Label = humanize(res.FileName)
+	}
+	if res.PluralLabel == "" {
+		res.PluralLabel = humanize(table)
+	}
+
+	seen := map[string]bool{}
+	for _, spec := range specs {
+		field, err := parseField(spec)
+		if err != nil {
+			return nil, err
+		}
+		if seen[field.Column] {
+			return nil, fmt.Errorf("alan birden fazla tanımlandı: %s", field.Column)
+		}
+		seen[field.Column] = true
+		res.Fields = append(res.Fields, field)
+	}
+	return res, nil
+}
+
+func parseField(spec string) (Field, error) {
+	parts := strings.Split(spec, ":")
+	if len(parts) < 2 {
+		return Field{}, fmt.Errorf("geçersiz alan tanımı %q, beklenen biçim ad:tip[:required|optional|unique|index|label=Etiket]", spec)
+	}
+	name, fieldType := parts[0], strings.ToLower(parts[1])
+	if !identifierPattern.MatchString(name) {
+		return Field{}, fmt.Errorf("geçersiz alan adı: %q", name)
+	}
+	if !isSupportedType(fieldType) {
+		return Field{}, fmt.Errorf("desteklenmeyen alan tipi %q (%s); desteklenenler: %s", fieldType, name, strings.Join(supportedTypes, ", "))
+	}
+
+	goName := toGoName(name)
+	field := Field{
+		GoName:   goName,
+		Column:   namer.ColumnName("", goName),
+		Type:     fieldType,
+		Required: fieldType == TypeString,
+	}
+	if reservedColumns[field.Column] {
+		return Field{}, fmt.Errorf("%s alanı otomatik olarak tanımlanıyor, kullanılamaz", field.Column)
+	}
+	field.Label = humanize(field.Column)
+
+	for _, modifier := range parts[2:] {
+		switch {
+		case modifier == "required":
+			field.Required = true
+		case modifier == "optional":
+			field.Required = false
+		case modifier == "unique":
+			field.Unique = true
+		case modifier == "index":
+			field.Index = true
+		case strings.HasPrefix(modifier, "label="):
+			field.Label = strings.TrimPrefix(modifier, "label=")
+		default:
+			return Field{}, fmt.Errorf("bilinmeyen alan seçeneği %q (%s)", modifier, name)
+		}
+	}
+	if field.Type == TypeBool {
+		field.Required = false
+	}
+	return field, nil
+}
+
+func (f Field) GoType() string {
+	switch f.Type {
+	case TypeInt:
+		return "int"
+	case TypeUint:
+		return "uint"
+	case TypeFloat:
+		return "float64"
+	case TypeBool:
+		return "bool"
+	case TypeDate, TypeDateTime:
+		return "*time.Time"
+	default:
+		return "string"
+	}
+}
+
+func (f Field) GormTag() string {
+	var parts []string
+	switch f.Type {
+	case TypeString:
+		parts = append(parts, "size:255")
+	case TypeText:
+		parts = append(parts, "type:text")
+	case TypeDate:
+		parts = append(parts, "type:date")
+	}
+	if f.Type == TypeBool {
+		parts = append(parts, "not null", "default:false")
+	} else if f.Required && f.Type != TypeDate && f.Type != TypeDateTime {
+		parts = append(parts, "not null")
+	}
+	if f.Unique {
+		parts = append(parts, "unique")
+	} else if f.Index {
+		parts = append(parts, "index")
+	}
+	return strings.Join(parts, ";")
+}
+
+func (f Field) Validate() string {
+	var rules []string
+	if f.Required {
+		rules = append(rules, "required")
+	} else if f.Type != TypeBool {
+		rules = append(rules, "omitempty")
+	}
+	switch f.Type {
+	case TypeString:
+		rules = append(rules, "max=255")
+	case TypeInt, TypeUint:
+		rules = append(rules, "integer")
+	case TypeFloat:
+		rules = append(rules, "number")
+	case TypeDate:
+		rules = append(rules, "date")
+	case TypeDateTime:
+		rules = append(rules, "datetime")
+	}
+	if len(rules) == 1 && rules[0] == "omitempty" {
+		return ""
+	}
+	return strings.Join(rules, ",")
+}
+
+func (f Field) FromForm(receiver string) string {
+	value := receiver + "." + f.GoName
+	switch f.Type {
+	case TypeInt:
+		return "formvalues.Int(" + value + ")"
+	case TypeUint:
+		return "formvalues.Uint(" + value + ")"
+	case TypeFloat:
+		return "formvalues.Float(" + value + ")"
+	case TypeBool:
+		return "formvalues.Bool(" + value + ")"
+	case TypeDate:
+		return "formvalues.Date(" + value + ")"
+	case TypeDateTime:
+		return "formvalues.DateTime(" + value + ")"
+	default:
+		return value
+	}
+}
+
+func (f Field) InputType() string {
+	switch f.Type {
+	case TypeInt, TypeUint, TypeFloat:
+		return "number"
+	case TypeDate:
+		return "date"
+	case TypeDateTime:
+		return "datetime-local"
+	default:
+		return "text"
+	}
+}
+
+func (f Field) InputStep() string {
+	switch f.Type {
+	case TypeInt, TypeUint:
+		return "1"
+	case TypeFloat:
+		return "any"
+	}
+	return ""
+}
+
+func (f Field) TimeLayout() string {
+	if f.Type == TypeDateTime {
+		return "2006-01-02T15:04"
+	}
+	return "2006-01-02"
+}
+
+func (f Field) Searchable() bool {
+	return f.Type == TypeString || f.Type == TypeText
+}
+
+func (f Field) Sortable() bool {
+	return f.Type != TypeText
+}
+
+func (f Field) IsTime() bool {
+	return f.Type == TypeDate || f.Type == TypeDateTime
+}
+
+func (r *Resource) SortColumns() string {
+	columns := []string{`"id"`}
+	for _, f := range r.Fields {
+		if f.Sortable() {
+			columns = append(columns, `"`+f.Column+`"`)
+		}
+	}
+	columns = append(columns, `"created_at"`)
+	return strings.Join(columns, ", ")
+}
+
+func (r *Resource) SearchColumns() string {
+	var columns []string
+	for _, f := range r.Fields {
+		if f.Searchable() {
+			columns = append(columns, `"`+f.Column+`"`)
+		}
+	}
+	return strings.Join(columns, ", ")
+}
+
+func (r *Resource) SearchLabel() string {
+	var labels []string
+	for _, f := range r.Fields {
+		if f.Searchable() {
+			labels = append(labels, f.Label)
+		}
+	}
+	return strings.Join(labels, "/")
+}
+
+func (r *Resource) NeedsTime() bool {
+	for _, f := range r.Fields {
+		if f.IsTime() {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Resource) NeedsFormValues() bool {
+	for _, f := range r.Fields {
+		if f.Type != TypeString && f.Type != TypeText {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Resource) HasBool() bool {
+	for _, f := range r.Fields {
+		if f.Type == TypeBool {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Resource) ColumnCount() int {
+	return len(r.Fields) + 3
+}
+
+func isSupportedType(fieldType string) bool {
+	for _, t := range supportedTypes {
+		if t == fieldType {
+			return true
+		}
+	}
+	return false
+}
+
+func toGoName(name string) string {
+	var builder strings.Builder
+	for _, part := range strings.Split(namer.ColumnName("", name), "_") {
+		if part == "" {
+			continue
+		}
+		if initialism, ok := commonInitialisms[part]; ok {
+			builder.WriteString(initialism)
+			continue
+		}
+		runes := []rune(part)
+		runes[0] = unicode.ToUpper(runes[0])
+		builder.WriteString(string(runes))
+	}
+	return builder.String()
+}
+
+func lowerFirst(name string) string {
+	runes := []rune(name)
+	for i, r := range runes {
+		if i > 0 && (i+1 >= len(runes) || !unicode.IsUpper(runes[i+1])) {
+			break
+		}
+		runes[i] = unicode.ToLower(r)
+	}
+	return string(runes)
+}
+
+func humanize(snake string) string {
+	words := strings.Split(snake, "_")
+	for i, word := range words {
+		if word == "" {
+			continue
+		}
+		if initialism, ok := commonInitialisms[word]; ok {
+			words[i] = initialism
+			continue
+		}
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		words[i] = string(runes)
+	}
+	return strings.Join(words, " ")
+}
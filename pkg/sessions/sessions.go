@@ -44,3 +44,8 @@ func GetUserStatusFromSession(sess *session.Session) (bool, error) {
 	return userStatus, nil
 
 }
+
+func GetOrganizationIDFromSession(sess *session.Session) uint {
+	organizationID, _ := sess.Get("organization_id").(uint)
+	return organizationID
+}
@@ -0,0 +1,157 @@
+package settings
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"zatrano/pkg/env"
+)
+
+type Type string
+
+const (
+	TypeString Type = "string"
+	TypeText   Type = "text"
+	TypeInt    Type = "int"
+	TypeBool   Type = "bool"
+)
+
+const (
+	SiteTitle              = "site.title"
+	SiteFooter             = "site.footer"
+	DefaultPerPage         = "list.default_per_page"
+	SessionExpirationHours = "session.expiration_hours"
+	LoginTitle             = "auth.login_title"
+	LoginNotice            = "auth.login_notice"
+	LoginSuccessMessage    = "auth.login_success_message"
+	InvalidLoginMessage    = "auth.invalid_credentials_message"
+	InactiveUserMessage    = "auth.inactive_user_message"
+	DashboardHomeTitle     = "dashboard.home_title"
+	PanelHomeTitle         = "panel.home_title"
+)
+
+type Category struct {
+	Key   string
+	Label string
+}
+
+var Categories = []Category{
+	{Key: "general", Label: "Genel"},
+	{Key: "list", Label: "Listeleme"},
+	{Key: "session", Label: "Oturum"},
+	{Key: "auth", Label: "Giriş"},
+	{Key: "titles", Label: "Sayfa Başlıkları"},
+}
+
+type Definition struct {
+	Key       string
+	Category  string
+	Label     string
+	Help      string
+	Type      Type
+	Default   string
+	Env       string
+	Required  bool
+	Min       int
+	Max       int
+	MaxLength int
+}
+
+var definitions = []Definition{
+	{Key: SiteTitle, Category: "general", Label: "Site Başlığı", Type: TypeString, Default: "Zatrano", Required: true, MaxLength: 100,
+		Help: "Tarayıcı sekmesinde ve menü başlığında gösterilir."},
+	{Key: SiteFooter, Category: "general", Label: "Alt Bilgi Metni", Type: TypeString, Default: "Tüm hakları saklıdır.", MaxLength: 255},
+	{Key: DefaultPerPage, Category: "list", Label: "Varsayılan Sayfa Boyutu", Type: TypeInt, Default: "20", Required: true, Min: 1, Max: 100,
+		Help: "Listelerde sayfa başına gösterilecek kayıt sayısı."},
+	{Key: SessionExpirationHours, Category: "session", Label: "Oturum Süresi (saat)", Type: TypeInt, Default: "24", Env: "SESSION_EXPIRATION_HOURS", Required: true, Min: 1, Max: 720,
+		Help: "Yeni açılan oturumlara uygulanır, açık oturumlar mevcut sürelerini korur."},
+	{Key: LoginTitle, Category: "auth", Label: "Giriş Sayfası Başlığı", Type: TypeString, Default: "Giriş", Required: true, MaxLength: 100},
+	{Key: LoginNotice, Category: "auth", Label: "Giriş Sayfası Duyurusu", Type: TypeText, MaxLength: 1000,
+		Help: "Boş bırakılırsa giriş sayfasında duyuru gösterilmez."},
+	{Key: LoginSuccessMessage, Category: "auth", Label: "Başarılı Giriş Mesajı", Type: TypeString, Default: "Başarıyla giriş yapıldı.", Required: true, MaxLength: 255},
+	{Key: InvalidLoginMessage, Category: "auth", Label: "Hatalı Giriş Mesajı", Type: TypeString, Default: "Kullanıcı adı veya şifre hatalı.", Required: true, MaxLength: 255},
+	{Key: InactiveUserMessage, Category: "auth", Label: "Pasif Hesap Mesajı", Type: TypeString, Default: "Hesabınız aktif değil. Lütfen yöneticinizle iletişime geçin.", Required: true, MaxLength: 255},
+	{Key: DashboardHomeTitle, Category: "titles", Label: "Yönetim Ana Sayfa Başlığı", Type: TypeString, Default: "Dashboard", Required: true, MaxLength: 100},
+	{Key: PanelHomeTitle, Category: "titles", Label: "Aracı Ana Sayfa Başlığı", Type: TypeString, Default: "Aracı Ana Sayfa", Required: true, MaxLength: 100},
+}
+
+var definitionsByKey = func() map[string]Definition {
+	byKey := make(map[string]Definition, len(definitions))
+	for _, d := range definitions {
+		byKey[d.Key] = d
+	}
+	return byKey
+}()
+
+func Definitions() []Definition {
+	out := make([]Definition, len(definitions))
+	copy(out, definitions)
+	return out
+}
+
+func Lookup(key string) (Definition, bool) {
+	d, ok := definitionsByKey[key]
+	return d, ok
+}
+
+func (d Definition) DefaultValue() string {
+	if d.Env != "" {
+		if value := env.GetEnvWithDefault(d.Env, ""); value != "" {
+			if _, err := d.Normalize(value); err == nil {
+				return value
+			}
+		}
+	}
+	return d.Default
+}
+
+func (d Definition) Normalize(raw string) (string, error) {
+	value := strings.TrimSpace(strings.ReplaceAll(raw, "\r\n", "\n"))
+
+	switch d.Type {
+	case TypeBool:
+		switch strings.ToLower(value) {
+		case "true", "on", "1":
+			return "true", nil
+		case "", "false", "off", "0":
+			return "false", nil
+		}
+		return "", fmt.Errorf("%s evet/hayır değeri olmalıdır", d.Label)
+	case TypeInt:
+		if value == "" {
+			return "", fmt.Errorf("%s alanı zorunludur", d.Label)
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return "", fmt.Errorf("%s tam sayı olmalıdır", d.Label)
+		}
+		if d.Min != 0 || d.Max != 0 {
+			if n < d.Min || (d.Max != 0 && n > d.Max) {
+				return "", fmt.Errorf("%s %d ile %d arasında olmalıdır", d.Label, d.Min, d.Max)
+			}
+		}
+		return strconv.Itoa(n), nil
+	default:
+		if d.Required && value == "" {
+			return "", fmt.Errorf("%s alanı zorunludur", d.Label)
+		}
+		if d.MaxLength > 0 && utf8.RuneCountInString(value) > d.MaxLength {
+			return "", fmt.Errorf("%s en fazla %d karakter olabilir", d.Label, d.MaxLength)
+		}
+		return value, nil
+	}
+}
+
+func (d Definition) Parse(value string) interface{} {
+	switch d.Type {
+	case TypeInt:
+		n, _ := strconv.Atoi(value)
+		return n
+	case TypeBool:
+		return value == "true"
+	default:
+		return value
+	}
+}
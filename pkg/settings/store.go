@@ -0,0 +1,126 @@
+package settings
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"zatrano/pkg/logs"
+
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	refreshInterval = 5 * time.Minute
+	retryInterval   = 30 * time.Second
+)
+
+type Loader func() (map[string]string, error)
+
+var store = struct {
+	mu         sync.RWMutex
+	group      singleflight.Group
+	loader     Loader
+	values     map[string]string
+	expiresAt  time.Time
+	generation uint64
+}{}
+
+func SetLoader(loader Loader) {
+	store.mu.Lock()
+	store.loader = loader
+	store.values = nil
+	store.expiresAt = time.Time{}
+	store.generation++
+	store.mu.Unlock()
+}
+
+func Invalidate() {
+	store.mu.Lock()
+	store.expiresAt = time.Time{}
+	store.generation++
+	store.mu.Unlock()
+}
+
+func snapshot() map[string]string {
+	store.mu.RLock()
+	values, expiresAt, loader, generation := store.values, store.expiresAt, store.loader, store.generation
+	store.mu.RUnlock()
+	if loader == nil || time.Now().Before(expiresAt) {
+		return orEmpty(values)
+	}
+
+	loaded, _, _ := store.group.Do(strconv.FormatUint(generation, 10), func() (interface{}, error) {
+		return load(loader, generation), nil
+	})
+	return loaded.(map[string]string)
+}
+
+func load(loader Loader, generation uint64) map[string]string {
+	loaded, err := loader()
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	current := store.generation == generation
+	if err != nil {
+		logs.Log.Error("Ayarlar veritabanından yüklenemedi, varsayılan değerler kullanılıyor", zap.Error(err))
+		if current {
+			store.expiresAt = time.Now().Add(retryInterval)
+		}
+		return orEmpty(store.values)
+	}
+	if current {
+		store.values = loaded
+		store.expiresAt = time.Now().Add(refreshInterval)
+	}
+	return loaded
+}
+
+func orEmpty(values map[string]string) map[string]string {
+	if values == nil {
+		return map[string]string{}
+	}
+	return values
+}
+
+func Raw(key string) string {
+	d, ok := Lookup(key)
+	if !ok {
+		logs.Log.Warn("Tanımsız ayar anahtarı istendi", zap.String("key", key))
+		return ""
+	}
+	if value, ok := snapshot()[key]; ok {
+		if normalized, err := d.Normalize(value); err == nil {
+			return normalized
+		}
+		logs.Log.Warn("Geçersiz ayar değeri, varsayılan kullanılıyor", zap.String("key", key), zap.String("value", value))
+	}
+	return d.DefaultValue()
+}
+
+func Value(key string) interface{} {
+	d, ok := Lookup(key)
+	if !ok {
+		return ""
+	}
+	return d.Parse(Raw(key))
+}
+
+func String(key string) string {
+	return Raw(key)
+}
+
+func Int(key string) int {
+	n, _ := Value(key).(int)
+	return n
+}
+
+func Bool(key string) bool {
+	b, _ := Value(key).(bool)
+	return b
+}
+
+func Hours(key string) time.Duration {
+	return time.Duration(Int(key)) * time.Hour
+}
@@ -0,0 +1,112 @@
+package settings
+
+import (
+	"errors"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"zatrano/pkg/logs"
+
+	"go.uber.org/zap"
+)
+
+func TestMain(m *testing.M) {
+	logs.Log = zap.NewNop()
+	logs.SLog = logs.Log.Sugar()
+	os.Exit(m.Run())
+}
+
+func TestSnapshotLoadsOnceForConcurrentReaders(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	SetLoader(func() (map[string]string, error) {
+		calls.Add(1)
+		<-release
+		return map[string]string{"site_name": "Zatrano"}, nil
+	})
+
+	var wg sync.WaitGroup
+	results := make([]map[string]string, 10)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = snapshot()
+		}(i)
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if n := calls.Load(); n != 1 {
+		t.Fatalf("yükleyici bir kez çağrılmalıydı, çağrı sayısı: %d", n)
+	}
+	for _, values := range results {
+		if values["site_name"] != "Zatrano" {
+			t.Fatalf("beklenmeyen ayarlar: %v", values)
+		}
+	}
+}
+
+func TestSnapshotWaitsBeforeRetryingFailedLoad(t *testing.T) {
+	var calls atomic.Int32
+	fail := atomic.Bool{}
+	SetLoader(func() (map[string]string, error) {
+		calls.Add(1)
+		if fail.Load() {
+			return nil, errors.New("bağlantı yok")
+		}
+		return map[string]string{"site_name": "Zatrano"}, nil
+	})
+	snapshot()
+
+	fail.Store(true)
+	Invalidate()
+	for i := 0; i < 3; i++ {
+		if values := snapshot(); values["site_name"] != "Zatrano" {
+			t.Fatalf("hata sonrası son yüklenen değerler kullanılmalı, dönen: %v", values)
+		}
+	}
+	if n := calls.Load(); n != 2 {
+		t.Fatalf("başarısız yükleme bekleme süresi dolmadan tekrarlanmamalı, çağrı sayısı: %d", n)
+	}
+
+	store.mu.Lock()
+	store.expiresAt = time.Now().Add(-time.Second)
+	store.mu.Unlock()
+	fail.Store(false)
+	snapshot()
+	if n := calls.Load(); n != 3 {
+		t.Fatalf("bekleme süresi dolunca yeniden denenmeli, çağrı sayısı: %d", n)
+	}
+}
+
+func TestInvalidateDuringLoadForcesReload(t *testing.T) {
+	var calls atomic.Int32
+	started, release := make(chan struct{}), make(chan struct{})
+	SetLoader(func() (map[string]string, error) {
+		if calls.Add(1) == 1 {
+			close(started)
+			<-release
+			return map[string]string{"site_name": "Eski"}, nil
+		}
+		return map[string]string{"site_name": "Yeni"}, nil
+	})
+
+	done := make(chan struct{})
+	go func() {
+		snapshot()
+		close(done)
+	}()
+	<-started
+	Invalidate()
+	close(release)
+	<-done
+
+	if values := snapshot(); values["site_name"] != "Yeni" {
+		t.Fatalf("geçersiz kılınan yükleme önbelleğe alınmamalı, dönen: %v", values)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"net/url"
 	"text/template"
 	"time"
+
+	"zatrano/pkg/settings"
 )
 
 func TemplateHelpers() template.FuncMap {
@@ -36,6 +38,7 @@ func TemplateHelpers() template.FuncMap {
 			return items
 		},
 		"urlquery": func(s string) string { return url.QueryEscape(s) },
+		"Setting":  settings.Value,
 		"dict": func(values ...interface{}) map[string]interface{} {
 			dict := make(map[string]interface{})
 			if len(values)%2 != 0 {
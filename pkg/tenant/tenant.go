@@ -0,0 +1,38 @@
+package tenant
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const Column = "organization_id"
+
+const contextOrganizationIDKey = "organization_id"
+
+func WithOrganization(ctx context.Context, organizationID uint) context.Context {
+	return context.WithValue(ctx, contextOrganizationIDKey, organizationID)
+}
+
+func OrganizationID(ctx context.Context) (uint, bool) {
+	if ctx == nil {
+		return 0, false
+	}
+	organizationID, ok := ctx.Value(contextOrganizationIDKey).(uint)
+	return organizationID, ok && organizationID != 0
+}
+
+func Scope(ctx context.Context) func(*gorm.DB) *gorm.DB {
+	return ScopeColumn(ctx, Column)
+}
+
+func ScopeColumn(ctx context.Context, column string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		organizationID, ok := OrganizationID(ctx)
+		if !ok {
+			return db
+		}
+		return db.Where(clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: column}, Value: organizationID})
+	}
+}
@@ -2,42 +2,185 @@ package turkishsearch
 
 import (
 	"strings"
+	"sync"
 	"unicode"
+
+	"zatrano/pkg/logs"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// foldFrom/foldTo must match the search_fold function created by migration 0011.
+const (
+	foldFrom     = "IİıÇçĞğÖöŞşÜüÂâÎîÛû"
+	foldTo       = "iiiccggoossuuaaiiuu"
+	foldFunction = "search_fold"
+	maxTerms     = 5
 )
 
-func normalize(str string) string {
-	replacements := map[rune]rune{
-		'ç': 'c', 'Ç': 'C',
-		'ğ': 'g', 'Ğ': 'G',
-		'ı': 'i', 'I': 'I',
-		'ö': 'o', 'Ö': 'O',
-		'ş': 's', 'Ş': 'S',
-		'ü': 'u', 'Ü': 'U',
+var foldReplacer = func() *strings.Replacer {
+	from, to := []rune(foldFrom), []rune(foldTo)
+	pairs := make([]string, 0, len(from)*2)
+	for i := range from {
+		pairs = append(pairs, string(from[i]), string(to[i]))
 	}
+	return strings.NewReplacer(pairs...)
+}()
 
-	var builder strings.Builder
-	for _, r := range str {
-		if repl, ok := replacements[r]; ok {
-			builder.WriteRune(unicode.ToLower(repl))
-		} else {
-			builder.WriteRune(unicode.ToLower(r))
+var (
+	extensionsMu    sync.RWMutex
+	dialect         string
+	hasUnaccent     bool
+	hasTrigram      bool
+	hasFoldFunction bool
+)
+
+func DetectExtensions(db *gorm.DB) {
+	extensionsMu.Lock()
+	defer extensionsMu.Unlock()
+
+	dialect = db.Dialector.Name()
+	hasUnaccent, hasTrigram, hasFoldFunction = false, false, false
+	if dialect != "postgres" {
+		return
+	}
+
+	var names []string
+	if err := db.Raw("SELECT extname FROM pg_extension WHERE extname IN ('unaccent', 'pg_trgm')").Scan(&names).Error; err != nil {
+		logs.Log.Warn("Arama eklentileri kontrol edilemedi, temel arama kullanılacak", zap.Error(err))
+		return
+	}
+	for _, name := range names {
+		switch name {
+		case "unaccent":
+			hasUnaccent = true
+		case "pg_trgm":
+			hasTrigram = true
 		}
 	}
+	if err := db.Raw("SELECT EXISTS (SELECT 1 FROM pg_proc WHERE proname = ?)", foldFunction).Scan(&hasFoldFunction).Error; err != nil {
+		logs.Log.Warn("Arama katlama fonksiyonu kontrol edilemedi", zap.Error(err))
+		hasFoldFunction = false
+	}
+	logs.Log.Info("Arama eklentileri algılandı",
+		zap.Bool("unaccent", hasUnaccent),
+		zap.Bool("pg_trgm", hasTrigram),
+		zap.Bool("search_fold", hasFoldFunction),
+	)
+}
+
+func Fold(str string) string {
+	folded := foldReplacer.Replace(str)
+	var builder strings.Builder
+	builder.Grow(len(folded))
+	for _, r := range folded {
+		builder.WriteRune(unicode.ToLower(r))
+	}
 	return builder.String()
 }
 
+func Terms(search string) []string {
+	seen := map[string]bool{}
+	var terms []string
+	for _, field := range strings.Fields(Fold(search)) {
+		if seen[field] {
+			continue
+		}
+		seen[field] = true
+		terms = append(terms, field)
+		if len(terms) == maxTerms {
+			break
+		}
+	}
+	return terms
+}
+
 func MatchNormalized(text, keyword string) bool {
-	normText := normalize(text)
-	normKeyword := normalize(keyword)
-	return strings.Contains(normText, normKeyword)
+	normText := Fold(text)
+	for _, term := range Terms(keyword) {
+		if !strings.Contains(normText, term) {
+			return false
+		}
+	}
+	return true
 }
 
-func SQLFilter(columnName, search string) (string, []interface{}) {
-	filterValue := "%" + strings.ToLower(search) + "%"
+func foldSQL(column string) string {
+	extensionsMu.RLock()
+	defer extensionsMu.RUnlock()
 
-	query := "unaccent(lower(" + columnName + ")) ILIKE unaccent($1)"
+	if dialect != "" && dialect != "postgres" {
+		expr := column
+		from, to := []rune(foldFrom), []rune(foldTo)
+		for i := range from {
+			expr = "replace(" + expr + ", '" + string(from[i]) + "', '" + string(to[i]) + "')"
+		}
+		return "lower(" + expr + ")"
+	}
+
+	if hasFoldFunction {
+		return foldFunction + "(" + column + ")"
+	}
+
+	expr := "lower(translate(" + column + ", '" + foldFrom + "', '" + foldTo + "'))"
+	if hasUnaccent {
+		expr = "unaccent(" + expr + ")"
+	}
+	return expr
+}
+
+func escapeLike(term string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(term)
+}
 
-	params := []interface{}{filterValue}
+func SQLFilter(search string, columns ...string) (string, []interface{}) {
+	terms := Terms(search)
+	if len(terms) == 0 || len(columns) == 0 {
+		return "", nil
+	}
 
-	return query, params
+	termClauses := make([]string, 0, len(terms))
+	params := make([]interface{}, 0, len(terms)*len(columns))
+	for _, term := range terms {
+		pattern := "%" + escapeLike(term) + "%"
+		columnClauses := make([]string, 0, len(columns))
+		for _, column := range columns {
+			columnClauses = append(columnClauses, foldSQL(column)+` LIKE ? ESCAPE '\'`)
+			params = append(params, pattern)
+		}
+		termClauses = append(termClauses, "("+strings.Join(columnClauses, " OR ")+")")
+	}
+
+	return strings.Join(termClauses, " AND "), params
+}
+
+func RankExpression(search string, columns ...string) (string, []interface{}, bool) {
+	extensionsMu.RLock()
+	trigram := hasTrigram
+	extensionsMu.RUnlock()
+
+	query := strings.Join(Terms(search), " ")
+	if !trigram || query == "" || len(columns) == 0 {
+		return "", nil, false
+	}
+
+	parts := make([]string, 0, len(columns))
+	params := make([]interface{}, 0, len(columns))
+	for _, column := range columns {
+		parts = append(parts, "similarity("+foldSQL(column)+", ?)")
+		params = append(params, query)
+	}
+	if len(parts) == 1 {
+		return parts[0], params, true
+	}
+	return "GREATEST(" + strings.Join(parts, ", ") + ")", params, true
+}
+
+func Apply(query *gorm.DB, search string, columns ...string) *gorm.DB {
+	fragment, params := SQLFilter(search, columns...)
+	if fragment == "" {
+		return query
+	}
+	return query.Where(fragment, params...)
 }
@@ -0,0 +1,161 @@
+package turkishsearch
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func useDialect(t *testing.T, name string, unaccent, trigram, foldFunc bool) {
+	t.Helper()
+	extensionsMu.Lock()
+	prev := []interface{}{dialect, hasUnaccent, hasTrigram, hasFoldFunction}
+	dialect, hasUnaccent, hasTrigram, hasFoldFunction = name, unaccent, trigram, foldFunc
+	extensionsMu.Unlock()
+	t.Cleanup(func() {
+		extensionsMu.Lock()
+		dialect, hasUnaccent, hasTrigram, hasFoldFunction = prev[0].(string), prev[1].(bool), prev[2].(bool), prev[3].(bool)
+		extensionsMu.Unlock()
+	})
+}
+
+func TestFold(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		// I, İ and ı all fold to i so "isik", "ışık" and "IŞIK" find the same rows.
+		{in: "IŞIK", want: "isik"},
+		{in: "ışık", want: "isik"},
+		{in: "İstanbul", want: "istanbul"},
+		{in: "ÇĞÖŞÜ çğöşü", want: "cgosu cgosu"},
+		{in: "Âlâ Îmân Ûmûr", want: "ala iman umur"},
+		{in: "Hello World", want: "hello world"},
+		{in: "", want: ""},
+	}
+	for _, tt := range tests {
+		if got := Fold(tt.in); got != tt.want {
+			t.Errorf("Fold(%q) = %q, beklenen %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestTerms(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{in: "  ", want: nil},
+		{in: "Işık ışık ISIK", want: []string{"isik"}},
+		{in: "a b c d e f g", want: []string{"a", "b", "c", "d", "e"}},
+	}
+	for _, tt := range tests {
+		if got := Terms(tt.in); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Terms(%q) = %v, beklenen %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestMatchNormalized(t *testing.T) {
+	tests := []struct {
+		text    string
+		keyword string
+		want    bool
+	}{
+		{text: "Ayşe Işık", keyword: "isik ayse", want: true},
+		{text: "Ayşe Işık", keyword: "AYŞE", want: true},
+		{text: "Ayşe Işık", keyword: "ayse yilmaz", want: false},
+		{text: "Ayşe Işık", keyword: "", want: true},
+	}
+	for _, tt := range tests {
+		if got := MatchNormalized(tt.text, tt.keyword); got != tt.want {
+			t.Errorf("MatchNormalized(%q, %q) = %v, beklenen %v", tt.text, tt.keyword, got, tt.want)
+		}
+	}
+}
+
+func TestSQLFilter(t *testing.T) {
+	tests := []struct {
+		name     string
+		dialect  string
+		unaccent bool
+		foldFunc bool
+		search   string
+		columns  []string
+		contains []string
+		params   []interface{}
+	}{
+		{
+			name: "empty search", dialect: "sqlite", search: " ", columns: []string{"name"},
+		},
+		{
+			name: "no columns", dialect: "sqlite", search: "ali",
+		},
+		{
+			name: "sqlite uses replace", dialect: "sqlite", search: "Işık", columns: []string{"name"},
+			contains: []string{"lower(replace(", "'İ', 'i'", `LIKE ? ESCAPE '\'`},
+			params:   []interface{}{"%isik%"},
+		},
+		{
+			name: "terms AND columns OR", dialect: "sqlite", search: "ali veli", columns: []string{"name", "account"},
+			contains: []string{`'\' OR lower(`, `'\') AND (lower(`},
+			params:   []interface{}{"%ali%", "%ali%", "%veli%", "%veli%"},
+		},
+		{
+			name: "like wildcards escaped", dialect: "sqlite", search: `%50_off\`, columns: []string{"name"},
+			params: []interface{}{`%\%50\_off\\%`},
+		},
+		{
+			name: "postgres translate", dialect: "postgres", search: "ali", columns: []string{"name"},
+			contains: []string{"lower(translate(name, '" + foldFrom + "', '" + foldTo + "'))"},
+			params:   []interface{}{"%ali%"},
+		},
+		{
+			name: "postgres unaccent", dialect: "postgres", unaccent: true, search: "ali", columns: []string{"name"},
+			contains: []string{"unaccent(lower(translate(name"},
+			params:   []interface{}{"%ali%"},
+		},
+		{
+			name: "postgres fold function", dialect: "postgres", unaccent: true, foldFunc: true, search: "ali", columns: []string{"name"},
+			contains: []string{"(search_fold(name) LIKE ?"},
+			params:   []interface{}{"%ali%"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useDialect(t, tt.dialect, tt.unaccent, false, tt.foldFunc)
+			fragment, params := SQLFilter(tt.search, tt.columns...)
+			if tt.params == nil {
+				if fragment != "" || params != nil {
+					t.Fatalf("boş filtre bekleniyordu, dönen %q %v", fragment, params)
+				}
+				return
+			}
+			for _, part := range tt.contains {
+				if !strings.Contains(fragment, part) {
+					t.Errorf("%q içinde %q bekleniyordu", fragment, part)
+				}
+			}
+			if !reflect.DeepEqual(params, tt.params) {
+				t.Errorf("parametreler %v, beklenen %v", params, tt.params)
+			}
+		})
+	}
+}
+
+func TestRankExpression(t *testing.T) {
+	useDialect(t, "postgres", false, false, true)
+	if _, _, ok := RankExpression("ali", "name"); ok {
+		t.Fatal("pg_trgm yokken sıralama ifadesi üretilmemeli")
+	}
+
+	useDialect(t, "postgres", false, true, true)
+	expr, params, ok := RankExpression("Ali VELİ", "name", "account")
+	if !ok || expr != "GREATEST(similarity(search_fold(name), ?), similarity(search_fold(account), ?))" {
+		t.Fatalf("beklenmeyen ifade: %q", expr)
+	}
+	if !reflect.DeepEqual(params, []interface{}{"ali veli", "ali veli"}) {
+		t.Fatalf("beklenmeyen parametreler: %v", params)
+	}
+}
@@ -0,0 +1,36 @@
+package validation
+
+var messages = map[string]map[string]string{
+	"tr": {
+		"required": "%[1]s alanı zorunludur.",
+		"min":      "%[1]s en az %[2]s karakter olmalıdır.",
+		"max":      "%[1]s en fazla %[2]s karakter olabilir.",
+		"email":    "%[1]s geçerli bir e-posta adresi olmalıdır.",
+		"account":  "%[1]s yalnızca harf, rakam ve . _ - @ karakterlerini içerebilir.",
+		"integer":  "%[1]s tam sayı olmalıdır.",
+		"number":   "%[1]s sayı olmalıdır.",
+		"date":     "%[1]s geçerli bir tarih olmalıdır.",
+		"datetime": "%[1]s geçerli bir tarih ve saat olmalıdır.",
+		"enum":     "%[1]s için geçersiz bir değer seçildi.",
+		"oneof":    "%[1]s için geçersiz bir değer seçildi.",
+		"eqfield":  "%[1]s, %[2]s ile aynı olmalıdır.",
+		"nefield":  "%[1]s, %[2]s ile aynı olamaz.",
+		"invalid":  "%[1]s geçersiz.",
+	},
+	"en": {
+		"required": "%[1]s is required.",
+		"min":      "%[1]s must be at least %[2]s characters.",
+		"max":      "%[1]s must be at most %[2]s characters.",
+		"email":    "%[1]s must be a valid e-mail address.",
+		"account":  "%[1]s may only contain letters, digits and . _ - @ characters.",
+		"integer":  "%[1]s must be an integer.",
+		"number":   "%[1]s must be a number.",
+		"date":     "%[1]s must be a valid date.",
+		"datetime": "%[1]s must be a valid date and time.",
+		"enum":     "%[1]s has an invalid value.",
+		"oneof":    "%[1]s has an invalid value.",
+		"eqfield":  "%[1]s must match %[2]s.",
+		"nefield":  "%[1]s must differ from %[2]s.",
+		"invalid":  "%[1]s is invalid.",
+	},
+}
@@ -0,0 +1,286 @@
+package validation
+
+import (
+	"fmt"
+	"net/mail"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	DefaultLanguage = "tr"
+	DateLayout      = "2006-01-02"
+	DateTimeLayout  = "2006-01-02T15:04"
+)
+
+type Errors map[string]string
+
+func (e Errors) Error() string {
+	parts := make([]string, 0, len(e))
+	for _, msg := range e {
+		parts = append(parts, msg)
+	}
+	return strings.Join(parts, " ")
+}
+
+func (e Errors) Has(field string) bool {
+	_, ok := e[field]
+	return ok
+}
+
+var accountPattern = regexp.MustCompile(`^[a-zA-Z0-9._@-]+$`)
+
+var (
+	enumsMu sync.RWMutex
+	enums   = map[string][]string{}
+)
+
+func RegisterEnum(name string, values ...string) {
+	enumsMu.Lock()
+	defer enumsMu.Unlock()
+	enums[name] = values
+}
+
+func enumContains(name, value string) bool {
+	enumsMu.RLock()
+	defer enumsMu.RUnlock()
+	for _, v := range enums[name] {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+type rule struct {
+	name  string
+	param string
+	limit int
+	other int
+}
+
+type fieldRules struct {
+	index     int
+	key       string
+	label     string
+	rules     []rule
+	omitEmpty bool
+}
+
+var knownRules = map[string]bool{
+	"omitempty": true, "required": true, "min": true, "max": true, "email": true, "account": true,
+	"integer": true, "number": true, "date": true, "datetime": true, "enum": true, "oneof": true,
+	"eqfield": true, "nefield": true,
+}
+
+var typeRules sync.Map
+
+func MustParse(values ...interface{}) {
+	for _, v := range values {
+		typ := reflect.TypeOf(v)
+		for typ != nil && typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+		if typ == nil || typ.Kind() != reflect.Struct {
+			panic(fmt.Sprintf("validation: %T bir struct değil", v))
+		}
+		rulesFor(typ)
+	}
+}
+
+func rulesFor(typ reflect.Type) []fieldRules {
+	if cached, ok := typeRules.Load(typ); ok {
+		return cached.([]fieldRules)
+	}
+	parsed, _ := typeRules.LoadOrStore(typ, parseType(typ))
+	return parsed.([]fieldRules)
+}
+
+func parseType(typ reflect.Type) []fieldRules {
+	var fields []fieldRules
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tag := field.Tag.Get("validate")
+		if tag == "" || !field.IsExported() {
+			continue
+		}
+		parsed := fieldRules{index: i, key: fieldKey(field), label: fieldLabel(field)}
+		for _, part := range strings.Split(tag, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			r := parseRule(typ, field, part)
+			if r.name == "omitempty" {
+				parsed.omitEmpty = true
+				continue
+			}
+			parsed.rules = append(parsed.rules, r)
+		}
+		fields = append(fields, parsed)
+	}
+	return fields
+}
+
+func parseRule(typ reflect.Type, field reflect.StructField, part string) rule {
+	name, param, _ := strings.Cut(part, "=")
+	r := rule{name: name, param: param}
+	if !knownRules[name] {
+		panic(fmt.Sprintf("validation: %s.%s alanında bilinmeyen kural %q", typ.Name(), field.Name, name))
+	}
+
+	switch name {
+	case "min", "max":
+		n, err := strconv.Atoi(param)
+		if err != nil || n < 0 {
+			panic(fmt.Sprintf("validation: %s.%s alanındaki %s kuralı tam sayı bekler, verilen %q", typ.Name(), field.Name, name, param))
+		}
+		r.limit = n
+	case "enum", "oneof":
+		if strings.TrimSpace(param) == "" {
+			panic(fmt.Sprintf("validation: %s.%s alanındaki %s kuralı değer bekler", typ.Name(), field.Name, name))
+		}
+	case "eqfield", "nefield":
+		other, ok := typ.FieldByName(param)
+		if !ok || len(other.Index) != 1 {
+			panic(fmt.Sprintf("validation: %s.%s alanındaki %s kuralının hedefi %q bulunamadı", typ.Name(), field.Name, name, param))
+		}
+		r.other = other.Index[0]
+		r.param = fieldLabel(other)
+	}
+	return r
+}
+
+func Validate(v interface{}) Errors {
+	return ValidateWithLanguage(v, DefaultLanguage)
+}
+
+func ValidateWithLanguage(v interface{}, lang string) Errors {
+	val := reflect.ValueOf(v)
+	for val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return nil
+		}
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Struct {
+		return nil
+	}
+
+	errs := Errors{}
+	for _, field := range rulesFor(val.Type()) {
+		value := stringValue(val.Field(field.index))
+		if value == "" && field.omitEmpty {
+			continue
+		}
+
+		for _, r := range field.rules {
+			if ok := check(r, value, val); !ok {
+				errs[field.key] = message(lang, r, field.label)
+				break
+			}
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+func check(r rule, value string, parent reflect.Value) bool {
+	switch r.name {
+	case "required":
+		return strings.TrimSpace(value) != ""
+	case "min":
+		return utf8.RuneCountInString(value) >= r.limit
+	case "max":
+		return utf8.RuneCountInString(value) <= r.limit
+	case "email":
+		addr, err := mail.ParseAddress(value)
+		return err == nil && addr.Address == value
+	case "account":
+		return accountPattern.MatchString(value)
+	case "integer":
+		_, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		return err == nil
+	case "number":
+		_, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		return err == nil
+	case "date":
+		_, err := time.Parse(DateLayout, value)
+		return err == nil
+	case "datetime":
+		_, err := time.Parse(DateTimeLayout, value)
+		return err == nil
+	case "enum":
+		return enumContains(r.param, value)
+	case "oneof":
+		for _, option := range strings.Fields(r.param) {
+			if option == value {
+				return true
+			}
+		}
+		return false
+	case "eqfield":
+		return stringValue(parent.Field(r.other)) == value
+	case "nefield":
+		return stringValue(parent.Field(r.other)) != value
+	}
+	panic(fmt.Sprintf("validation: bilinmeyen kural %q", r.name))
+}
+
+func fieldKey(field reflect.StructField) string {
+	if name := strings.Split(field.Tag.Get("form"), ",")[0]; name != "" {
+		return name
+	}
+	return field.Name
+}
+
+func fieldLabel(field reflect.StructField) string {
+	if label := field.Tag.Get("label"); label != "" {
+		return label
+	}
+	return field.Name
+}
+
+func stringValue(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return ""
+		}
+		return stringValue(v.Elem())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v.Int() == 0 {
+			return ""
+		}
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if v.Uint() == 0 {
+			return ""
+		}
+		return strconv.FormatUint(v.Uint(), 10)
+	default:
+		return fmt.Sprint(v.Interface())
+	}
+}
+
+func message(lang string, r rule, label string) string {
+	catalog, ok := messages[lang]
+	if !ok {
+		catalog = messages[DefaultLanguage]
+	}
+	format, ok := catalog[r.name]
+	if !ok {
+		format = catalog["invalid"]
+	}
+	return fmt.Sprintf(format, label, r.param)
+}
@@ -0,0 +1,114 @@
+package validation
+
+import (
+	"testing"
+)
+
+type signupForm struct {
+	Name     string `form:"name" validate:"required,min=3,max=5" label:"Ad"`
+	Email    string `form:"email" validate:"omitempty,email" label:"E-posta"`
+	Account  string `form:"account" validate:"omitempty,account" label:"Hesap"`
+	Age      string `form:"age" validate:"omitempty,integer" label:"Yaş"`
+	Score    string `form:"score" validate:"omitempty,number" label:"Puan"`
+	Birthday string `form:"birthday" validate:"omitempty,date" label:"Doğum Tarihi"`
+	StartsAt string `form:"starts_at" validate:"omitempty,datetime" label:"Başlangıç"`
+	Kind     string `form:"kind" validate:"omitempty,enum=test_kind" label:"Tür"`
+	Color    string `form:"color" validate:"omitempty,oneof=red blue" label:"Renk"`
+	Password string `form:"password" validate:"omitempty,nefield=Name" label:"Şifre"`
+	Confirm  string `form:"confirm" validate:"eqfield=Password" label:"Şifre (Tekrar)"`
+	Level    int    `form:"level" validate:"omitempty,max=1"`
+}
+
+func TestValidate(t *testing.T) {
+	RegisterEnum("test_kind", "a", "b")
+
+	tests := []struct {
+		name  string
+		form  signupForm
+		field string
+		msg   string
+	}{
+		{name: "valid", form: signupForm{Name: "Ayşe"}},
+		{name: "required", form: signupForm{Name: "  "}, field: "name", msg: "Ad alanı zorunludur."},
+		{name: "min counts runes", form: signupForm{Name: "Şü"}, field: "name", msg: "Ad en az 3 karakter olmalıdır."},
+		{name: "max counts runes", form: signupForm{Name: "ĞüŞİÖÇ"}, field: "name", msg: "Ad en fazla 5 karakter olabilir."},
+		{name: "email", form: signupForm{Name: "Ayşe", Email: "Ayşe <a@b.c>"}, field: "email"},
+		{name: "account", form: signupForm{Name: "Ayşe", Account: "ayşe"}, field: "account"},
+		{name: "integer", form: signupForm{Name: "Ayşe", Age: "1.5"}, field: "age"},
+		{name: "number", form: signupForm{Name: "Ayşe", Score: "1.5"}},
+		{name: "date", form: signupForm{Name: "Ayşe", Birthday: "2024-02-30"}, field: "birthday"},
+		{name: "datetime", form: signupForm{Name: "Ayşe", StartsAt: "2024-02-01 10:00"}, field: "starts_at"},
+		{name: "enum", form: signupForm{Name: "Ayşe", Kind: "c"}, field: "kind"},
+		{name: "oneof", form: signupForm{Name: "Ayşe", Color: "green"}, field: "color"},
+		{name: "nefield", form: signupForm{Name: "Ayşe", Password: "Ayşe", Confirm: "Ayşe"}, field: "password", msg: "Şifre, Ad ile aynı olamaz."},
+		{name: "eqfield", form: signupForm{Name: "Ayşe", Password: "secret", Confirm: "other"}, field: "confirm", msg: "Şifre (Tekrar), Şifre ile aynı olmalıdır."},
+		{name: "non-string field without label", form: signupForm{Name: "Ayşe", Level: 12}, field: "level", msg: "Level en fazla 1 karakter olabilir."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := Validate(&tt.form)
+			if tt.field == "" {
+				if errs != nil {
+					t.Fatalf("hata beklenmiyordu, dönen: %v", errs)
+				}
+				return
+			}
+			if len(errs) != 1 || !errs.Has(tt.field) {
+				t.Fatalf("yalnızca %s alanında hata bekleniyordu, dönen: %v", tt.field, errs)
+			}
+			if tt.msg != "" && errs[tt.field] != tt.msg {
+				t.Fatalf("beklenen mesaj %q, dönen %q", tt.msg, errs[tt.field])
+			}
+		})
+	}
+}
+
+func TestValidateWithLanguage(t *testing.T) {
+	errs := ValidateWithLanguage(signupForm{}, "en")
+	if errs["name"] != "Ad is required." {
+		t.Fatalf("İngilizce mesaj bekleniyordu, dönen: %v", errs)
+	}
+	errs = ValidateWithLanguage(signupForm{}, "xx")
+	if errs["name"] != "Ad alanı zorunludur." {
+		t.Fatalf("bilinmeyen dilde varsayılan mesaj bekleniyordu, dönen: %v", errs)
+	}
+}
+
+func TestMustParseRejectsBadTags(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+	}{
+		{name: "unknown rule", value: struct {
+			A string `validate:"requird"`
+		}{}},
+		{name: "non-numeric min", value: struct {
+			A string `validate:"min=abc"`
+		}{}},
+		{name: "missing max param", value: struct {
+			A string `validate:"max"`
+		}{}},
+		{name: "empty oneof", value: struct {
+			A string `validate:"oneof="`
+		}{}},
+		{name: "missing eqfield target", value: struct {
+			A string `validate:"eqfield=B"`
+		}{}},
+		{name: "missing nefield target", value: struct {
+			A string `validate:"nefield=B"`
+		}{}},
+		{name: "not a struct", value: "text"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Fatal("hatalı etiket panic üretmeliydi")
+				}
+			}()
+			MustParse(tt.value)
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"zatrano/configs"
+	"zatrano/models"
+	"zatrano/pkg/domainerrors"
+	"zatrano/pkg/tenant"
+
+	"gorm.io/gorm"
+)
+
+type AuditFilter struct {
+	ActorID    uint
+	Action     string
+	EntityType string
+	EntityID   uint
+	RequestID  string
+	From       *time.Time
+	To         *time.Time
+}
+
+type IAuditRepository interface {
+	Find(ctx context.Context, filter AuditFilter, offset, limit int) ([]models.AuditLog, int64, error)
+	GetEntityTypes(ctx context.Context) ([]string, error)
+	GetActorNames(ids []uint) (map[uint]string, error)
+}
+
+type AuditRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditRepository() IAuditRepository {
+	return &AuditRepository{db: configs.GetDB()}
+}
+
+func (r *AuditRepository) Find(ctx context.Context, filter AuditFilter, offset, limit int) ([]models.AuditLog, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.AuditLog{}).Scopes(tenant.Scope(ctx))
+	if filter.ActorID != 0 {
+		query = query.Where("actor_id = ?", filter.ActorID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != 0 {
+		query = query.Where("entity_id = ?", filter.EntityID)
+	}
+	if filter.RequestID != "" {
+		query = query.Where("request_id = ?", filter.RequestID)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, domainerrors.FromDB(err)
+	}
+
+	var rows []models.AuditLog
+	err := query.Order("created_at DESC").Order("id DESC").Offset(offset).Limit(limit).Find(&rows).Error
+	if err != nil {
+		return nil, 0, domainerrors.FromDB(err)
+	}
+	return rows, total, nil
+}
+
+func (r *AuditRepository) GetEntityTypes(ctx context.Context) ([]string, error) {
+	var types []string
+	err := r.db.WithContext(ctx).Model(&models.AuditLog{}).Scopes(tenant.Scope(ctx)).Distinct("entity_type").Order("entity_type").Pluck("entity_type", &types).Error
+	return types, domainerrors.FromDB(err)
+}
+
+func (r *AuditRepository) GetActorNames(ids []uint) (map[uint]string, error) {
+	names := make(map[uint]string, len(ids))
+	if len(ids) == 0 {
+		return names, nil
+	}
+	var users []models.User
+	if err := r.db.Unscoped().Select("id", "name", "account").Where("id IN ?", ids).Find(&users).Error; err != nil {
+		return nil, domainerrors.FromDB(err)
+	}
+	for _, user := range users {
+		names[user.ID] = user.Name + " (" + user.Account + ")"
+	}
+	return names, nil
+}
+
+var _ IAuditRepository = (*AuditRepository)(nil)
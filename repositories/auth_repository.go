@@ -1,16 +1,26 @@
 package repositories
 
 import (
+	"context"
+
 	"zatrano/configs"
 	"zatrano/models"
+	"zatrano/pkg/audit"
+	"zatrano/pkg/domainerrors"
+	"zatrano/pkg/logs"
+	"zatrano/pkg/redact"
+	"zatrano/pkg/replica"
+	"zatrano/pkg/tenant"
 
+	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
 
 type IAuthRepository interface {
 	FindUserByAccount(account string) (*models.User, error)
-	FindUserByID(id uint) (*models.User, error)
-	UpdateUser(user *models.User) error
+	FindUserByID(ctx context.Context, id uint) (*models.User, error)
+	UpdatePassword(ctx context.Context, userID uint, hashedPassword string) error
+	CreateLoginEvent(event *models.LoginEvent) error
 }
 
 type AuthRepository struct {
@@ -25,22 +35,56 @@ func (r *AuthRepository) FindUserByAccount(account string) (*models.User, error)
 	var user models.User
 	err := r.db.Where("account = ?", account).First(&user).Error
 	if err != nil {
-		return nil, err
+		return nil, domainerrors.FromDB(err)
 	}
 	return &user, nil
 }
 
-func (r *AuthRepository) FindUserByID(id uint) (*models.User, error) {
+func (r *AuthRepository) FindUserByID(ctx context.Context, id uint) (*models.User, error) {
 	var user models.User
-	err := r.db.First(&user, id).Error
+	err := replica.Reader(ctx, r.db).WithContext(ctx).First(&user, id).Error
 	if err != nil {
-		return nil, err
+		return nil, domainerrors.FromDB(err)
 	}
 	return &user, nil
 }
 
-func (r *AuthRepository) UpdateUser(user *models.User) error {
-	return r.db.Save(user).Error
+func (r *AuthRepository) UpdatePassword(ctx context.Context, userID uint, hashedPassword string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var user models.User
+		if err := tx.Select("id", "organization_id", "must_change_password").First(&user, userID).Error; err != nil {
+			return domainerrors.FromDB(err)
+		}
+
+		err := tx.Model(&models.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+			"password":             hashedPassword,
+			"must_change_password": false,
+			"version":              gorm.Expr("version + 1"),
+		}).Error
+		if err != nil {
+			return domainerrors.FromDB(err)
+		}
+
+		changes := models.AuditChanges{"password": {Old: redact.Value, New: redact.Value}}
+		if user.MustChangePassword {
+			changes["must_change_password"] = models.AuditChange{Old: true, New: false}
+		}
+		err = audit.Record(tenant.WithOrganization(ctx, user.OrganizationID), tx, audit.Entry{
+			Action:     audit.ActionUpdate,
+			EntityType: "users",
+			EntityID:   userID,
+			Changes:    changes,
+		})
+		if err != nil {
+			logs.Log.Error("Şifre değişikliği denetim kaydı yazılamadı", zap.Uint("user_id", userID), zap.Error(err))
+			return domainerrors.Internal("denetim kaydı oluşturulamadı", err)
+		}
+		return nil
+	})
+}
+
+func (r *AuthRepository) CreateLoginEvent(event *models.LoginEvent) error {
+	return domainerrors.FromDB(r.db.Create(event).Error)
 }
 
 var _ IAuthRepository = (*AuthRepository)(nil)
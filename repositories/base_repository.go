@@ -0,0 +1,380 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"reflect"
+	"strings"
+
+	"zatrano/pkg/audit"
+	"zatrano/pkg/domainerrors"
+	"zatrano/pkg/logs"
+	"zatrano/pkg/queryparams"
+	"zatrano/pkg/replica"
+	"zatrano/pkg/tenant"
+	"zatrano/pkg/turkishsearch"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+type Sortable interface {
+	SortableColumns() []string
+}
+
+type Searchable interface {
+	SearchColumns() []string
+}
+
+type Preloadable interface {
+	Preloads() []string
+}
+
+type DBErrorTranslator interface {
+	TranslateDBError(err error) error
+}
+
+type Scope = func(*gorm.DB) *gorm.DB
+
+type ContextScope func(ctx context.Context) Scope
+
+var defaultSortColumns = []string{"id", "created_at", "updated_at"}
+
+var ErrVersionConflict = domainerrors.Conflict("kayıt başka bir işlem tarafından değiştirildi")
+
+var ErrVersionRequired = domainerrors.Validation("kayıt sürümü eksik, sayfayı yenileyip tekrar deneyin").WithField("version")
+
+type IRepository[T any] interface {
+	GetAll(ctx context.Context, params queryparams.ListParams, scopes ...Scope) ([]T, int64, error)
+	GetAllCursor(ctx context.Context, params queryparams.ListParams, scopes ...Scope) ([]T, *queryparams.CursorMeta, error)
+	GetByID(ctx context.Context, id uint) (*T, error)
+	GetCount(ctx context.Context) (int64, error)
+	Create(ctx context.Context, entity *T) error
+	Update(ctx context.Context, id uint, version uint, data map[string]interface{}, updatedByID uint) error
+	Delete(ctx context.Context, id uint) error
+}
+
+type Repository[T any] struct {
+	db          *gorm.DB
+	model       T
+	table       string
+	tenantField *schema.Field
+	scopes      []ContextScope
+	inTx        bool
+}
+
+func NewRepository[T any](db *gorm.DB) *Repository[T] {
+	r := &Repository[T]{db: db}
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(&r.model); err == nil {
+		r.table = stmt.Schema.Table
+		r.tenantField = stmt.Schema.LookUpField(tenant.Column)
+	}
+	return r
+}
+
+func (r *Repository[T]) DB() *gorm.DB {
+	return r.db
+}
+
+func (r *Repository[T]) AddScope(scope ContextScope) {
+	r.scopes = append(r.scopes, scope)
+}
+
+func (r *Repository[T]) withTx(tx *gorm.DB) *Repository[T] {
+	clone := *r
+	clone.db = tx
+	clone.inTx = true
+	return &clone
+}
+
+func (r *Repository[T]) GetAll(ctx context.Context, params queryparams.ListParams, scopes ...Scope) ([]T, int64, error) {
+	var rows []T
+	var totalCount int64
+
+	query := r.searchQuery(ctx, params).Scopes(scopes...)
+
+	err := query.Count(&totalCount).Error
+	if err != nil {
+		logs.Log.Error("Kayıt sayısı alınırken hata (GetAll)", zap.String("table", r.table), zap.Error(err))
+		return nil, 0, domainerrors.FromDB(err)
+	}
+
+	if totalCount == 0 {
+		return rows, 0, nil
+	}
+
+	sortBy, orderBy := r.sortColumns(params)
+	if params.Name != "" && params.SortBy == "" {
+		if rank, rankParams, ok := turkishsearch.RankExpression(params.Name, r.searchColumns()...); ok {
+			query = query.Order(clause.Expr{SQL: rank + " DESC", Vars: rankParams})
+		}
+	}
+	query = query.Order(sortBy + " " + orderBy)
+	query = r.preload(query)
+
+	offset := params.CalculateOffset()
+	query = query.Limit(params.PerPage).Offset(offset)
+
+	err = query.Find(&rows).Error
+	if err != nil {
+		logs.Log.Error("Kayıt verisi çekilirken hata (GetAll)", zap.String("table", r.table), zap.Error(err))
+		return nil, totalCount, domainerrors.FromDB(err)
+	}
+
+	return rows, totalCount, nil
+}
+
+func (r *Repository[T]) GetAllCursor(ctx context.Context, params queryparams.ListParams, scopes ...Scope) ([]T, *queryparams.CursorMeta, error) {
+	query := r.preload(r.searchQuery(ctx, params).Scopes(scopes...))
+
+	sortBy, orderBy := r.sortColumns(params)
+	return paginateKeyset[T](r.reader(ctx), query, params, sortBy, orderBy)
+}
+
+func (r *Repository[T]) GetByID(ctx context.Context, id uint) (*T, error) {
+	var entity T
+	err := r.preload(r.scoped(ctx, r.reader(ctx))).First(&entity, id).Error
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			logs.Log.Error("GetByID sırasında DB hatası", zap.String("table", r.table), zap.Uint("id", id), zap.Error(err))
+		}
+		return nil, r.translate(err)
+	}
+	return &entity, nil
+}
+
+func (r *Repository[T]) GetCount(ctx context.Context) (int64, error) {
+	var count int64
+	err := r.scoped(ctx, r.reader(ctx)).Model(new(T)).Count(&count).Error
+	if err != nil {
+		logs.Log.Error("Count sırasında DB hatası", zap.String("table", r.table), zap.Error(err))
+	}
+	return count, domainerrors.FromDB(err)
+}
+
+func (r *Repository[T]) Create(ctx context.Context, entity *T) error {
+	if err := r.assignTenant(ctx, entity); err != nil {
+		logs.Log.Error("Create sırasında organizasyon atanamadı", zap.String("table", r.table), zap.Error(err))
+		return domainerrors.Internal("kayıt organizasyona atanamadı", err)
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(entity).Error; err != nil {
+			logs.Log.Error("Create sırasında DB hatası", zap.String("table", r.table), zap.Error(err))
+			return r.translate(err)
+		}
+		return r.recordAudit(ctx, tx, audit.ActionCreate, audit.PrimaryKey(tx, entity), nil, entity)
+	})
+	return err
+}
+
+func (r *Repository[T]) Update(ctx context.Context, id uint, version uint, data map[string]interface{}, updatedByID uint) error {
+	if len(data) == 0 {
+		logs.Log.Debug("Repository.Update: Güncellenecek veri yok.", zap.String("table", r.table), zap.Uint("id", id))
+		return nil
+	}
+
+	if version == 0 {
+		logs.Log.Warn("Repository.Update: Sürüm bilgisi olmadan güncelleme reddedildi.", zap.String("table", r.table), zap.Uint("id", id))
+		return ErrVersionRequired
+	}
+
+	data = maps.Clone(data)
+	if updatedByID != 0 {
+		data["updated_by"] = updatedByID
+	} else {
+		logs.Log.Warn("Repository.Update: Geçersiz updatedByID (0) alındı, updated_by alanı ayarlanamadı.",
+			zap.String("table", r.table),
+			zap.Uint("id", id))
+	}
+
+	if r.tenantField != nil {
+		if _, scoped := tenant.OrganizationID(ctx); scoped {
+			delete(data, r.tenantField.DBName)
+		}
+	}
+
+	data["version"] = gorm.Expr("version + 1")
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var before T
+		hasBefore := r.scoped(ctx, tx).First(&before, id).Error == nil
+
+		result := r.scoped(ctx, tx).Model(new(T)).Where("id = ? AND version = ?", id, version).Updates(data)
+
+		if result.Error != nil {
+			logs.Log.Error("Update sırasında DB hatası", zap.String("table", r.table), zap.Uint("id", id), zap.Error(result.Error))
+			return r.translate(result.Error)
+		}
+
+		if result.RowsAffected == 0 {
+			if hasBefore {
+				logs.Log.Warn("Repository.Update: Sürüm çakışması, kayıt başka bir işlem tarafından değiştirilmiş.",
+					zap.String("table", r.table),
+					zap.Uint("id", id),
+					zap.Uint("expected_version", version))
+				return ErrVersionConflict
+			}
+			logs.Log.Warn("Repository.Update: Kayıt bulunamadı veya hiçbir alan değişmedi.",
+				zap.String("table", r.table),
+				zap.Uint("id", id),
+				zap.Int64("rows_affected", result.RowsAffected))
+			return domainerrors.NotFound("kayıt bulunamadı")
+		}
+
+		var after T
+		if err := r.scoped(ctx, tx).First(&after, id).Error; err != nil {
+			logs.Log.Error("Update sonrası kayıt okunamadı", zap.String("table", r.table), zap.Uint("id", id), zap.Error(err))
+			return domainerrors.FromDB(err)
+		}
+		return r.recordAudit(ctx, tx, audit.ActionUpdate, id, &before, &after)
+	})
+}
+
+func (r *Repository[T]) Delete(ctx context.Context, id uint) error {
+	userID, ok := ctx.Value("user_id").(uint)
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var entity T
+
+		findTx := r.scoped(ctx, tx).First(&entity, id)
+		if findTx.Error != nil {
+			if errors.Is(findTx.Error, gorm.ErrRecordNotFound) {
+				logs.Log.Warn("Repository.Delete: Silinecek kayıt bulunamadı", zap.String("table", r.table), zap.Uint("id", id))
+			} else {
+				logs.Log.Error("Delete sırasında kayıt bulunurken DB hatası", zap.String("table", r.table), zap.Uint("id", id), zap.Error(findTx.Error))
+			}
+			return domainerrors.FromDB(findTx.Error)
+		}
+
+		if !ok || userID == 0 {
+			return domainerrors.Forbidden("işlemi yapan kullanıcı kimliği belirlenemedi")
+		}
+
+		updateTx := tx.Model(&entity).Update("deleted_by", userID)
+		if updateTx.Error != nil {
+			logs.Log.Error("deleted_by güncellenirken hata", zap.String("table", r.table), zap.Error(updateTx.Error))
+			return domainerrors.FromDB(updateTx.Error)
+		}
+
+		deleteTx := tx.Delete(&entity)
+		if deleteTx.Error != nil {
+			logs.Log.Error("Delete sırasında DB hatası", zap.String("table", r.table), zap.Uint("id", id), zap.Error(deleteTx.Error))
+			return domainerrors.FromDB(deleteTx.Error)
+		}
+
+		if deleteTx.RowsAffected == 0 {
+			logs.Log.Warn("Repository.Delete: Silme işlemi 0 satırı etkiledi", zap.String("table", r.table), zap.Uint("id", id))
+		}
+
+		return r.recordAudit(ctx, tx, audit.ActionDelete, id, &entity, nil)
+	})
+}
+
+func (r *Repository[T]) recordAudit(ctx context.Context, tx *gorm.DB, action string, id uint, before, after *T) error {
+	var beforeValue, afterValue interface{}
+	if before != nil {
+		beforeValue = before
+	}
+	if after != nil {
+		afterValue = after
+	}
+
+	changes, err := audit.Diff(tx, beforeValue, afterValue)
+	if err != nil {
+		logs.Log.Error("Denetim kaydı için değişiklikler hesaplanamadı", zap.String("table", r.table), zap.Uint("id", id), zap.Error(err))
+		return domainerrors.Internal("denetim kaydı oluşturulamadı", err)
+	}
+	if err := audit.Record(ctx, tx, audit.Entry{Action: action, EntityType: r.table, EntityID: id, Changes: changes}); err != nil {
+		logs.Log.Error("Denetim kaydı yazılamadı", zap.String("table", r.table), zap.Uint("id", id), zap.String("action", action), zap.Error(err))
+		return domainerrors.Internal("denetim kaydı oluşturulamadı", err)
+	}
+	return nil
+}
+
+func (r *Repository[T]) searchQuery(ctx context.Context, params queryparams.ListParams) *gorm.DB {
+	return turkishsearch.Apply(r.scoped(ctx, r.reader(ctx)).Model(new(T)), params.Name, r.searchColumns()...)
+}
+
+func (r *Repository[T]) reader(ctx context.Context) *gorm.DB {
+	if r.inTx {
+		return r.db
+	}
+	return replica.Reader(ctx, r.db)
+}
+
+func (r *Repository[T]) scoped(ctx context.Context, db *gorm.DB) *gorm.DB {
+	db = db.WithContext(ctx)
+	if r.tenantField != nil {
+		db = db.Scopes(tenant.ScopeColumn(ctx, r.tenantField.DBName))
+	}
+	for _, scope := range r.scopes {
+		db = db.Scopes(scope(ctx))
+	}
+	return db
+}
+
+func (r *Repository[T]) assignTenant(ctx context.Context, entity *T) error {
+	if r.tenantField == nil {
+		return nil
+	}
+	organizationID, ok := tenant.OrganizationID(ctx)
+	if !ok {
+		return nil
+	}
+	return r.tenantField.Set(ctx, reflect.ValueOf(entity).Elem(), organizationID)
+}
+
+func (r *Repository[T]) sortColumns(params queryparams.ListParams) (string, string) {
+	sortBy := params.SortBy
+	orderBy := strings.ToLower(params.OrderBy)
+	if orderBy != "asc" && orderBy != "desc" {
+		orderBy = queryparams.DefaultOrderBy
+	}
+
+	allowed := defaultSortColumns
+	if sortable, ok := any(&r.model).(Sortable); ok {
+		allowed = sortable.SortableColumns()
+	}
+	for _, column := range allowed {
+		if column == sortBy {
+			return sortBy, orderBy
+		}
+	}
+	return queryparams.DefaultSortBy, orderBy
+}
+
+func (r *Repository[T]) searchColumns() []string {
+	if searchable, ok := any(&r.model).(Searchable); ok {
+		return searchable.SearchColumns()
+	}
+	return nil
+}
+
+func (r *Repository[T]) preload(query *gorm.DB) *gorm.DB {
+	preloadable, ok := any(&r.model).(Preloadable)
+	if !ok {
+		return query.Preload(clause.Associations)
+	}
+	for _, association := range preloadable.Preloads() {
+		query = query.Preload(association)
+	}
+	return query
+}
+
+func (r *Repository[T]) translate(err error) error {
+	err = domainerrors.FromDB(err)
+	if err == nil {
+		return nil
+	}
+	if translator, ok := any(&r.model).(DBErrorTranslator); ok {
+		return translator.TranslateDBError(err)
+	}
+	return err
+}
+
+var _ IRepository[struct{}] = (*Repository[struct{}])(nil)
@@ -0,0 +1,180 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"zatrano/configs"
+	"zatrano/models"
+	"zatrano/pkg/domainerrors"
+
+	"gorm.io/gorm"
+)
+
+const claimJobQuery = `UPDATE jobs SET status = ?, locked_by = ?, locked_at = ?, attempts = attempts + 1, updated_at = ?
+WHERE id = (SELECT id FROM jobs WHERE status = ? AND run_at <= ? ORDER BY run_at, id LIMIT 1%s) AND status = ?
+RETURNING *`
+
+type IJobRepository interface {
+	Create(ctx context.Context, job *models.Job) error
+	Claim(ctx context.Context, workerID string) (*models.Job, error)
+	Complete(ctx context.Context, id uint) error
+	Reschedule(ctx context.Context, id uint, runAt time.Time, lastError string) error
+	Fail(ctx context.Context, id uint, lastError string) error
+	RequeueStale(ctx context.Context, lockedBefore time.Time) (int64, error)
+	Retry(ctx context.Context, id uint) error
+	List(ctx context.Context, status string, offset, limit int) ([]models.Job, int64, error)
+	CountByStatus(ctx context.Context) (map[string]int64, error)
+	DeleteCompletedBefore(ctx context.Context, before time.Time) (int64, error)
+	HasUnfinished(ctx context.Context, jobType string) (bool, error)
+}
+
+type JobRepository struct {
+	db *gorm.DB
+}
+
+func NewJobRepository() IJobRepository {
+	return &JobRepository{db: configs.GetDB()}
+}
+
+func (r *JobRepository) Create(ctx context.Context, job *models.Job) error {
+	return domainerrors.FromDB(r.db.WithContext(ctx).Create(job).Error)
+}
+
+func (r *JobRepository) Claim(ctx context.Context, workerID string) (*models.Job, error) {
+	lock := ""
+	if r.db.Dialector.Name() == "postgres" {
+		lock = " FOR UPDATE SKIP LOCKED"
+	}
+
+	now := time.Now().UTC()
+	var jobs []models.Job
+	err := r.db.WithContext(ctx).Raw(fmt.Sprintf(claimJobQuery, lock),
+		models.JobRunning, workerID, now, now, models.JobPending, now, models.JobPending,
+	).Scan(&jobs).Error
+	if err != nil {
+		return nil, domainerrors.FromDB(err)
+	}
+	if len(jobs) == 0 {
+		return nil, nil
+	}
+	return &jobs[0], nil
+}
+
+func (r *JobRepository) Complete(ctx context.Context, id uint) error {
+	now := time.Now().UTC()
+	return r.finish(ctx, id, map[string]interface{}{
+		"status":       models.JobCompleted,
+		"completed_at": now,
+		"last_error":   "",
+	})
+}
+
+func (r *JobRepository) Reschedule(ctx context.Context, id uint, runAt time.Time, lastError string) error {
+	return r.finish(ctx, id, map[string]interface{}{
+		"status":     models.JobPending,
+		"run_at":     runAt.UTC(),
+		"last_error": lastError,
+	})
+}
+
+func (r *JobRepository) Fail(ctx context.Context, id uint, lastError string) error {
+	return r.finish(ctx, id, map[string]interface{}{
+		"status":     models.JobFailed,
+		"failed_at":  time.Now().UTC(),
+		"last_error": lastError,
+	})
+}
+
+func (r *JobRepository) finish(ctx context.Context, id uint, values map[string]interface{}) error {
+	values["locked_by"] = ""
+	values["locked_at"] = nil
+	values["updated_at"] = time.Now().UTC()
+	err := r.db.WithContext(ctx).Model(&models.Job{}).Where("id = ? AND status = ?", id, models.JobRunning).UpdateColumns(values).Error
+	return domainerrors.FromDB(err)
+}
+
+func (r *JobRepository) RequeueStale(ctx context.Context, lockedBefore time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&models.Job{}).
+		Where("status = ? AND locked_at < ?", models.JobRunning, lockedBefore.UTC()).
+		UpdateColumns(map[string]interface{}{
+			"status":     models.JobPending,
+			"locked_by":  "",
+			"locked_at":  nil,
+			"last_error": "çalışan süreç işi tamamlamadan durdu",
+			"updated_at": time.Now().UTC(),
+		})
+	return result.RowsAffected, domainerrors.FromDB(result.Error)
+}
+
+func (r *JobRepository) Retry(ctx context.Context, id uint) error {
+	now := time.Now().UTC()
+	result := r.db.WithContext(ctx).Model(&models.Job{}).
+		Where("id = ? AND status = ?", id, models.JobFailed).
+		UpdateColumns(map[string]interface{}{
+			"status":     models.JobPending,
+			"attempts":   0,
+			"run_at":     now,
+			"failed_at":  nil,
+			"updated_at": now,
+		})
+	if result.Error != nil {
+		return domainerrors.FromDB(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domainerrors.NotFound("tekrar denenecek başarısız iş bulunamadı")
+	}
+	return nil
+}
+
+func (r *JobRepository) List(ctx context.Context, status string, offset, limit int) ([]models.Job, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.Job{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, domainerrors.FromDB(err)
+	}
+
+	var jobs []models.Job
+	err := query.Order("id DESC").Offset(offset).Limit(limit).Find(&jobs).Error
+	if err != nil {
+		return nil, 0, domainerrors.FromDB(err)
+	}
+	return jobs, total, nil
+}
+
+func (r *JobRepository) CountByStatus(ctx context.Context) (map[string]int64, error) {
+	var rows []struct {
+		Status string
+		Count  int64
+	}
+	err := r.db.WithContext(ctx).Model(&models.Job{}).Select("status, COUNT(*) AS count").Group("status").Scan(&rows).Error
+	if err != nil {
+		return nil, domainerrors.FromDB(err)
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
+
+func (r *JobRepository) DeleteCompletedBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("status = ? AND completed_at < ?", models.JobCompleted, before.UTC()).Delete(&models.Job{})
+	return result.RowsAffected, domainerrors.FromDB(result.Error)
+}
+
+func (r *JobRepository) HasUnfinished(ctx context.Context, jobType string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.Job{}).
+		Where("type = ? AND status IN ?", jobType, []string{models.JobPending, models.JobRunning}).
+		Count(&count).Error
+	return count > 0, domainerrors.FromDB(err)
+}
+
+var _ IJobRepository = (*JobRepository)(nil)
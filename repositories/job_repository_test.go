@@ -0,0 +1,145 @@
+package repositories_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"zatrano/configs"
+	"zatrano/models"
+	"zatrano/pkg/domainerrors"
+	"zatrano/repositories"
+)
+
+func TestJobClaimFailAndRetry(t *testing.T) {
+	ctx := context.Background()
+	repo := repositories.NewJobRepository()
+
+	job := &models.Job{Type: "test.claim", Payload: "{}", Status: models.JobPending, MaxAttempts: 3, RunAt: time.Now().UTC().Add(-time.Second)}
+	if err := repo.Create(ctx, job); err != nil {
+		t.Fatal(err)
+	}
+	later := &models.Job{Type: "test.claim", Payload: "{}", Status: models.JobPending, MaxAttempts: 3, RunAt: time.Now().UTC().Add(time.Hour)}
+	if err := repo.Create(ctx, later); err != nil {
+		t.Fatal(err)
+	}
+
+	claimed, err := repo.Claim(ctx, "worker-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claimed == nil || claimed.ID != job.ID {
+		t.Fatalf("zamanı gelen iş alınmalıydı, dönen: %+v", claimed)
+	}
+	if claimed.Status != models.JobRunning || claimed.Attempts != 1 || claimed.LockedBy != "worker-1" {
+		t.Fatalf("alınan iş çalışıyor olarak işaretlenmedi: %+v", claimed)
+	}
+
+	if again, err := repo.Claim(ctx, "worker-2"); err != nil || again != nil {
+		t.Fatalf("zamanı gelmemiş veya kilitli iş alınmamalı, dönen: %+v, %v", again, err)
+	}
+
+	if err := repo.Fail(ctx, job.ID, "boom"); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Retry(ctx, job.ID); err != nil {
+		t.Fatalf("başarısız iş tekrar kuyruğa alınamadı: %v", err)
+	}
+	if err := repo.Retry(ctx, job.ID); !domainerrors.IsNotFound(err) {
+		t.Fatalf("bekleyen iş tekrar denenememeli, dönen: %v", err)
+	}
+
+	retried, err := repo.Claim(ctx, "worker-2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if retried == nil || retried.ID != job.ID || retried.Attempts != 1 {
+		t.Fatalf("tekrar denenen iş sıfırlanmış deneme sayısıyla alınmalıydı, dönen: %+v", retried)
+	}
+	if err := repo.Complete(ctx, job.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	pending, err := repo.HasUnfinished(ctx, "test.claim")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !pending {
+		t.Fatal("ileri tarihli iş bekleyen olarak görülmeli")
+	}
+}
+
+func TestJobRequeueStale(t *testing.T) {
+	ctx := context.Background()
+	repo := repositories.NewJobRepository()
+
+	job := &models.Job{Type: "test.stale", Payload: "{}", Status: models.JobPending, MaxAttempts: 3, RunAt: time.Now().UTC().Add(-time.Second)}
+	if err := repo.Create(ctx, job); err != nil {
+		t.Fatal(err)
+	}
+	claimed, err := repo.Claim(ctx, "worker-stale")
+	if err != nil || claimed == nil || claimed.ID != job.ID {
+		t.Fatalf("iş alınamadı: %+v, %v", claimed, err)
+	}
+
+	count, err := repo.RequeueStale(ctx, time.Now().Add(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Fatalf("1 kilitli işin kuyruğa dönmesi bekleniyordu, dönen: %d", count)
+	}
+
+	requeued, err := repo.Claim(ctx, "worker-stale")
+	if err != nil || requeued == nil || requeued.ID != job.ID || requeued.Attempts != 2 {
+		t.Fatalf("kuyruğa dönen iş yeniden alınmalıydı: %+v, %v", requeued, err)
+	}
+	if err := repo.Complete(ctx, job.ID); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestJobRescheduleAndPurge(t *testing.T) {
+	ctx := context.Background()
+	repo := repositories.NewJobRepository()
+
+	job := &models.Job{Type: "test.purge", Payload: "{}", Status: models.JobPending, MaxAttempts: 3, RunAt: time.Now().UTC().Add(-time.Second)}
+	if err := repo.Create(ctx, job); err != nil {
+		t.Fatal(err)
+	}
+	if claimed, err := repo.Claim(ctx, "worker-purge"); err != nil || claimed == nil || claimed.ID != job.ID {
+		t.Fatalf("iş alınamadı: %+v, %v", claimed, err)
+	}
+	if err := repo.Reschedule(ctx, job.ID, time.Now().Add(time.Hour), "geçici hata"); err != nil {
+		t.Fatal(err)
+	}
+	if claimed, err := repo.Claim(ctx, "worker-purge"); err != nil || claimed != nil {
+		t.Fatalf("ileri tarihe ertelenen iş alınmamalı: %+v, %v", claimed, err)
+	}
+	if unfinished, err := repo.HasUnfinished(ctx, "test.purge"); err != nil || !unfinished {
+		t.Fatalf("ertelenen iş bitmemiş sayılmalı: %v, %v", unfinished, err)
+	}
+
+	configs.GetDB().Model(job).UpdateColumn("run_at", time.Now().UTC().Add(-time.Second))
+	if claimed, err := repo.Claim(ctx, "worker-purge"); err != nil || claimed == nil || claimed.ID != job.ID {
+		t.Fatalf("zamanı gelen iş alınamadı: %+v, %v", claimed, err)
+	}
+	if err := repo.Complete(ctx, job.ID); err != nil {
+		t.Fatal(err)
+	}
+	if unfinished, err := repo.HasUnfinished(ctx, "test.purge"); err != nil || unfinished {
+		t.Fatalf("tamamlanan iş bitmemiş sayılmamalı: %v, %v", unfinished, err)
+	}
+
+	exists := func() bool {
+		var count int64
+		configs.GetDB().Model(&models.Job{}).Where("id = ?", job.ID).Count(&count)
+		return count > 0
+	}
+	if _, err := repo.DeleteCompletedBefore(ctx, time.Now().Add(-time.Minute)); err != nil || !exists() {
+		t.Fatalf("yeni tamamlanan iş silinmemeli: %v", err)
+	}
+	if _, err := repo.DeleteCompletedBefore(ctx, time.Now().Add(time.Minute)); err != nil || exists() {
+		t.Fatalf("eski tamamlanmış iş silinmeliydi: %v", err)
+	}
+}
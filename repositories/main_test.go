@@ -0,0 +1,12 @@
+package repositories_test
+
+import (
+	"os"
+	"testing"
+
+	"zatrano/database/testdb"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(testdb.Run(m))
+}
@@ -0,0 +1,95 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"zatrano/configs"
+	"zatrano/models"
+	"zatrano/pkg/domainerrors"
+	"zatrano/pkg/tenant"
+
+	"gorm.io/gorm"
+)
+
+const notificationBatchSize = 500
+
+type INotificationRepository interface {
+	Create(ctx context.Context, notifications []models.Notification) error
+	FindActiveUserIDsByType(ctx context.Context, userType models.UserType) ([]uint, error)
+	CountUnread(userID uint) (int64, error)
+	ListForUser(userID uint, unreadOnly bool, offset, limit int) ([]models.Notification, int64, error)
+	GetForUser(userID, id uint) (*models.Notification, error)
+	MarkRead(userID, id uint) error
+	MarkAllRead(userID uint) (int64, error)
+}
+
+type NotificationRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepository() INotificationRepository {
+	return &NotificationRepository{db: configs.GetDB()}
+}
+
+func (r *NotificationRepository) Create(ctx context.Context, notifications []models.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+	return domainerrors.FromDB(r.db.WithContext(ctx).CreateInBatches(notifications, notificationBatchSize).Error)
+}
+
+func (r *NotificationRepository) FindActiveUserIDsByType(ctx context.Context, userType models.UserType) ([]uint, error) {
+	var ids []uint
+	err := r.db.WithContext(ctx).Model(&models.User{}).Scopes(tenant.Scope(ctx)).Where("type = ? AND status = ?", userType, true).Pluck("id", &ids).Error
+	return ids, domainerrors.FromDB(err)
+}
+
+func (r *NotificationRepository) CountUnread(userID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Notification{}).Where("user_id = ? AND read_at IS NULL", userID).Count(&count).Error
+	return count, domainerrors.FromDB(err)
+}
+
+func (r *NotificationRepository) ListForUser(userID uint, unreadOnly bool, offset, limit int) ([]models.Notification, int64, error) {
+	query := r.db.Model(&models.Notification{}).Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, domainerrors.FromDB(err)
+	}
+
+	var rows []models.Notification
+	err := query.Order("created_at DESC").Order("id DESC").Offset(offset).Limit(limit).Find(&rows).Error
+	if err != nil {
+		return nil, 0, domainerrors.FromDB(err)
+	}
+	return rows, total, nil
+}
+
+func (r *NotificationRepository) GetForUser(userID, id uint) (*models.Notification, error) {
+	var notification models.Notification
+	if err := r.db.Where("id = ? AND user_id = ?", id, userID).First(&notification).Error; err != nil {
+		return nil, domainerrors.FromDB(err)
+	}
+	return &notification, nil
+}
+
+func (r *NotificationRepository) MarkRead(userID, id uint) error {
+	err := r.db.Model(&models.Notification{}).
+		Where("id = ? AND user_id = ? AND read_at IS NULL", id, userID).
+		Update("read_at", time.Now()).Error
+	return domainerrors.FromDB(err)
+}
+
+func (r *NotificationRepository) MarkAllRead(userID uint) (int64, error) {
+	result := r.db.Model(&models.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Update("read_at", time.Now())
+	return result.RowsAffected, domainerrors.FromDB(result.Error)
+}
+
+var _ INotificationRepository = (*NotificationRepository)(nil)
@@ -0,0 +1,94 @@
+package repositories
+
+import (
+	"context"
+
+	"zatrano/configs"
+	"zatrano/models"
+	"zatrano/pkg/audit"
+	"zatrano/pkg/domainerrors"
+
+	"gorm.io/gorm"
+)
+
+type OrganizationSummary struct {
+	models.Organization
+	UserCount int64
+}
+
+type IOrganizationRepository interface {
+	GetAll() ([]models.Organization, error)
+	GetSummaries() ([]OrganizationSummary, error)
+	GetByID(id uint) (*models.Organization, error)
+	Create(ctx context.Context, organization *models.Organization) error
+	UpdateStatus(ctx context.Context, id uint, status bool) error
+}
+
+type OrganizationRepository struct {
+	db *gorm.DB
+}
+
+func NewOrganizationRepository() IOrganizationRepository {
+	return &OrganizationRepository{db: configs.GetDB()}
+}
+
+func (r *OrganizationRepository) GetAll() ([]models.Organization, error) {
+	var organizations []models.Organization
+	err := r.db.Order("name").Find(&organizations).Error
+	return organizations, domainerrors.FromDB(err)
+}
+
+func (r *OrganizationRepository) GetSummaries() ([]OrganizationSummary, error) {
+	var summaries []OrganizationSummary
+	err := r.db.Model(&models.Organization{}).
+		Select("organizations.*, (SELECT COUNT(*) FROM users WHERE users.organization_id = organizations.id AND users.deleted_at IS NULL) AS user_count").
+		Order("organizations.name").
+		Scan(&summaries).Error
+	return summaries, domainerrors.FromDB(err)
+}
+
+func (r *OrganizationRepository) GetByID(id uint) (*models.Organization, error) {
+	var organization models.Organization
+	if err := r.db.First(&organization, id).Error; err != nil {
+		return nil, domainerrors.FromDB(err)
+	}
+	return &organization, nil
+}
+
+func (r *OrganizationRepository) Create(ctx context.Context, organization *models.Organization) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(organization).Error; err != nil {
+			return organization.TranslateDBError(domainerrors.FromDB(err))
+		}
+		changes := models.AuditChanges{
+			"name":   {New: organization.Name},
+			"status": {New: organization.Status},
+		}
+		if err := audit.Record(ctx, tx, audit.Entry{Action: audit.ActionCreate, EntityType: "organizations", EntityID: organization.ID, Changes: changes}); err != nil {
+			return domainerrors.Internal("denetim kaydı oluşturulamadı", err)
+		}
+		return nil
+	})
+}
+
+func (r *OrganizationRepository) UpdateStatus(ctx context.Context, id uint, status bool) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var organization models.Organization
+		if err := tx.First(&organization, id).Error; err != nil {
+			return domainerrors.FromDB(err)
+		}
+		if organization.Status == status {
+			return nil
+		}
+		if err := tx.Model(&organization).Update("status", status).Error; err != nil {
+			return domainerrors.FromDB(err)
+		}
+		changes := models.AuditChanges{"status": {Old: !status, New: status}}
+		if err := audit.Record(ctx, tx, audit.Entry{Action: audit.ActionUpdate, EntityType: "organizations", EntityID: id, Changes: changes}); err != nil {
+			return domainerrors.Internal("denetim kaydı oluşturulamadı", err)
+		}
+		return nil
+	})
+}
+
+var _ IOrganizationRepository = (*OrganizationRepository)(nil)
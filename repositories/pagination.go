@@ -0,0 +1,173 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"zatrano/pkg/domainerrors"
+	"zatrano/pkg/logs"
+	"zatrano/pkg/queryparams"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func paginateKeyset[T any](db *gorm.DB, query *gorm.DB, params queryparams.ListParams, sortBy, orderBy string) ([]T, *queryparams.CursorMeta, error) {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(new(T)); err != nil {
+		return nil, nil, domainerrors.Internal("model şeması çözümlenemedi", err)
+	}
+	sortField := stmt.Schema.LookUpField(sortBy)
+	idField := stmt.Schema.PrioritizedPrimaryField
+	if sortField == nil || idField == nil {
+		return nil, nil, domainerrors.Internal(fmt.Sprintf("sıralama alanı bulunamadı: %s", sortBy), nil)
+	}
+
+	meta := &queryparams.CursorMeta{PerPage: params.PerPage}
+
+	total, estimated, err := countForCursor(db, query, params.Exact)
+	if err != nil {
+		return nil, nil, err
+	}
+	meta.TotalItems = total
+	meta.TotalEstimated = estimated
+
+	var cursor *queryparams.Cursor
+	if params.Cursor != "" {
+		decoded, decodeErr := queryparams.DecodeCursor(params.Cursor)
+		if decodeErr != nil {
+			logs.Log.Warn("Geçersiz sayfalama imleci, ilk sayfadan başlanıyor", zap.Error(decodeErr))
+		} else if decoded.SortBy == sortBy && decoded.OrderBy == orderBy {
+			cursor = decoded
+		}
+	}
+
+	forward := cursor == nil || cursor.Direction == queryparams.CursorNext
+	effectiveOrder := orderBy
+	if !forward {
+		effectiveOrder = reverseOrder(orderBy)
+	}
+	operator := ">"
+	if effectiveOrder == "desc" {
+		operator = "<"
+	}
+
+	if cursor != nil {
+		value, valueErr := cursor.SortValue()
+		if valueErr != nil {
+			return nil, nil, domainerrors.Validation(queryparams.ErrInvalidCursor.Error())
+		}
+		query = query.Where(
+			fmt.Sprintf("(%s, %s) %s (?, ?)", stmt.Quote(sortField.DBName), stmt.Quote(idField.DBName), operator),
+			value, cursor.ID,
+		)
+	}
+
+	query = query.Order(sortField.DBName + " " + effectiveOrder)
+	if sortField.DBName != idField.DBName {
+		query = query.Order(idField.DBName + " " + effectiveOrder)
+	}
+
+	var rows []T
+	if err := query.Limit(params.PerPage + 1).Find(&rows).Error; err != nil {
+		logs.Log.Error("İmleç tabanlı sayfalama sorgusu başarısız", zap.Error(err))
+		return nil, nil, domainerrors.FromDB(err)
+	}
+
+	hasMore := len(rows) > params.PerPage
+	if hasMore {
+		rows = rows[:params.PerPage]
+	}
+	if !forward {
+		for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+			rows[i], rows[j] = rows[j], rows[i]
+		}
+	}
+
+	if forward {
+		meta.HasNext = hasMore
+		meta.HasPrev = cursor != nil
+	} else {
+		meta.HasPrev = hasMore
+		meta.HasNext = true
+	}
+
+	if len(rows) == 0 {
+		return rows, meta, nil
+	}
+
+	keyOf := func(row *T) (interface{}, uint) {
+		rv := reflect.ValueOf(row).Elem()
+		sortValue, _ := sortField.ValueOf(context.Background(), rv)
+		idValue, _ := idField.ValueOf(context.Background(), rv)
+		id, _ := idValue.(uint)
+		return sortValue, id
+	}
+
+	if meta.HasNext {
+		value, id := keyOf(&rows[len(rows)-1])
+		if c, err := queryparams.NewCursor(sortBy, orderBy, value, id, queryparams.CursorNext); err == nil {
+			meta.NextCursor = c.Encode()
+		}
+	}
+	if meta.HasPrev {
+		value, id := keyOf(&rows[0])
+		if c, err := queryparams.NewCursor(sortBy, orderBy, value, id, queryparams.CursorPrev); err == nil {
+			meta.PrevCursor = c.Encode()
+		}
+	}
+
+	return rows, meta, nil
+}
+
+func countForCursor(db *gorm.DB, query *gorm.DB, exact bool) (int64, bool, error) {
+	if !exact && db.Dialector.Name() == "postgres" {
+		estimate, err := estimateCount(db, query)
+		if err == nil {
+			return estimate, true, nil
+		}
+		logs.Log.Warn("Tahmini kayıt sayısı alınamadı, kesin sayıma geçiliyor", zap.Error(err))
+	}
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		logs.Log.Error("Kayıt sayısı alınırken hata", zap.Error(err))
+		return 0, false, domainerrors.FromDB(err)
+	}
+	return total, false, nil
+}
+
+func estimateCount(db *gorm.DB, query *gorm.DB) (int64, error) {
+	dry := query.Session(&gorm.Session{DryRun: true}).Select("1").Find(&[]map[string]interface{}{})
+	if dry.Error != nil {
+		return 0, dry.Error
+	}
+
+	var plan string
+	row := db.Statement.ConnPool.QueryRowContext(db.Statement.Context, "EXPLAIN (FORMAT JSON) "+dry.Statement.SQL.String(), dry.Statement.Vars...)
+	if err := row.Scan(&plan); err != nil {
+		return 0, err
+	}
+
+	var explained []struct {
+		Plan struct {
+			PlanRows float64 `json:"Plan Rows"`
+		} `json:"Plan"`
+	}
+	if err := json.Unmarshal([]byte(plan), &explained); err != nil {
+		return 0, err
+	}
+	if len(explained) == 0 {
+		return 0, fmt.Errorf("boş sorgu planı")
+	}
+	return int64(explained[0].Plan.PlanRows), nil
+}
+
+func reverseOrder(orderBy string) string {
+	if orderBy == "asc" {
+		return "desc"
+	}
+	return "asc"
+}
@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"zatrano/configs"
+	"zatrano/models"
+	"zatrano/pkg/audit"
+	"zatrano/pkg/domainerrors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ISettingRepository interface {
+	GetAll() ([]models.Setting, error)
+	SaveAll(ctx context.Context, values map[string]string, updatedByID uint) error
+}
+
+type SettingRepository struct {
+	db *gorm.DB
+}
+
+func NewSettingRepository() ISettingRepository {
+	return &SettingRepository{db: configs.GetDB()}
+}
+
+func (r *SettingRepository) GetAll() ([]models.Setting, error) {
+	var rows []models.Setting
+	if err := r.db.Order("key").Find(&rows).Error; err != nil {
+		return nil, domainerrors.FromDB(err)
+	}
+	return rows, nil
+}
+
+func (r *SettingRepository) SaveAll(ctx context.Context, values map[string]string, updatedByID uint) error {
+	if len(values) == 0 {
+		return nil
+	}
+
+	var updatedBy *uint
+	if updatedByID != 0 {
+		updatedBy = &updatedByID
+	}
+	now := time.Now()
+	rows := make([]models.Setting, 0, len(values))
+	for key, value := range values {
+		rows = append(rows, models.Setting{Key: key, Value: value, UpdatedBy: updatedBy, UpdatedAt: now})
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		keys := make([]string, 0, len(values))
+		for key := range values {
+			keys = append(keys, key)
+		}
+		var existing []models.Setting
+		if err := tx.Where("key IN ?", keys).Find(&existing).Error; err != nil {
+			return domainerrors.FromDB(err)
+		}
+		previous := make(map[string]string, len(existing))
+		for _, row := range existing {
+			previous[row.Key] = row.Value
+		}
+
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "key"}},
+			DoUpdates: clause.AssignmentColumns([]string{"value", "updated_by", "updated_at"}),
+		}).Create(&rows).Error
+		if err != nil {
+			return domainerrors.FromDB(err)
+		}
+
+		changes := models.AuditChanges{}
+		for key, value := range values {
+			change := models.AuditChange{New: value}
+			if old, ok := previous[key]; ok {
+				change.Old = old
+			}
+			changes[key] = change
+		}
+		if err := audit.Record(ctx, tx, audit.Entry{Action: audit.ActionUpdate, EntityType: "settings", Changes: changes}); err != nil {
+			return domainerrors.Internal("denetim kaydı oluşturulamadı", err)
+		}
+		return nil
+	})
+}
+
+var _ ISettingRepository = (*SettingRepository)(nil)
@@ -0,0 +1,156 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"zatrano/configs"
+	"zatrano/models"
+	"zatrano/pkg/domainerrors"
+	"zatrano/pkg/logs"
+	"zatrano/pkg/ownership"
+	"zatrano/pkg/replica"
+	"zatrano/pkg/tenant"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	BucketDay   = "day"
+	BucketWeek  = "week"
+	BucketMonth = "month"
+)
+
+type UserTypeStatusCount struct {
+	Type   models.UserType `json:"type"`
+	Status bool            `json:"status"`
+	Count  int64           `json:"count"`
+}
+
+type BucketCount struct {
+	Bucket string `json:"bucket"`
+	Count  int64  `json:"count"`
+}
+
+type LoginBucketCount struct {
+	Bucket      string `json:"bucket"`
+	Count       int64  `json:"count"`
+	UniqueUsers int64  `json:"unique_users"`
+}
+
+type IStatsRepository interface {
+	CountUsersByTypeAndStatus(ctx context.Context) ([]UserTypeStatusCount, error)
+	CountUsersCreated(ctx context.Context, from, to time.Time, bucket string) ([]BucketCount, error)
+	CountLogins(ctx context.Context, from, to time.Time) ([]LoginBucketCount, error)
+	GetRecentlyModifiedUsers(ctx context.Context, limit int) ([]models.User, error)
+}
+
+type StatsRepository struct {
+	db *gorm.DB
+}
+
+func NewStatsRepository() IStatsRepository {
+	return &StatsRepository{db: configs.GetDB()}
+}
+
+func (r *StatsRepository) CountUsersByTypeAndStatus(ctx context.Context) ([]UserTypeStatusCount, error) {
+	var rows []UserTypeStatusCount
+	err := r.reader(ctx).WithContext(ctx).Model(&models.User{}).Scopes(tenant.Scope(ctx), ownership.Scope(ctx)).
+		Select("type, status, COUNT(*) AS count").
+		Group("type, status").
+		Order("type, status").
+		Scan(&rows).Error
+	if err != nil {
+		logs.Log.Error("Tip ve duruma göre kullanıcı sayıları alınırken DB hatası", zap.Error(err))
+		return nil, domainerrors.FromDB(err)
+	}
+	return rows, nil
+}
+
+func (r *StatsRepository) CountUsersCreated(ctx context.Context, from, to time.Time, bucket string) ([]BucketCount, error) {
+	bucketExpr, err := r.bucketExpression("created_at", bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []BucketCount
+	err = r.reader(ctx).WithContext(ctx).Model(&models.User{}).Scopes(tenant.Scope(ctx), ownership.Scope(ctx)).
+		Select(bucketExpr+" AS bucket, COUNT(*) AS count").
+		Where("created_at >= ? AND created_at < ?", from, to).
+		Group("bucket").
+		Order("bucket").
+		Scan(&rows).Error
+	if err != nil {
+		logs.Log.Error("Dönemsel kullanıcı kayıt sayıları alınırken DB hatası", zap.String("bucket", bucket), zap.Error(err))
+		return nil, domainerrors.FromDB(err)
+	}
+	return rows, nil
+}
+
+func (r *StatsRepository) CountLogins(ctx context.Context, from, to time.Time) ([]LoginBucketCount, error) {
+	bucketExpr, err := r.bucketExpression("created_at", BucketDay)
+	if err != nil {
+		return nil, err
+	}
+
+	db := r.reader(ctx)
+	query := db.WithContext(ctx).Model(&models.LoginEvent{})
+	_, tenantScoped := tenant.OrganizationID(ctx)
+	_, ownerScoped := ownership.ManagerID(ctx)
+	if tenantScoped || ownerScoped {
+		query = query.Where("user_id IN (?)", db.Unscoped().Model(&models.User{}).Select("id").Scopes(tenant.Scope(ctx), ownership.Scope(ctx)))
+	}
+
+	var rows []LoginBucketCount
+	err = query.
+		Select(bucketExpr+" AS bucket, COUNT(*) AS count, COUNT(DISTINCT user_id) AS unique_users").
+		Where("created_at >= ? AND created_at < ?", from, to).
+		Group("bucket").
+		Order("bucket").
+		Scan(&rows).Error
+	if err != nil {
+		logs.Log.Error("Günlük giriş sayıları alınırken DB hatası", zap.Error(err))
+		return nil, domainerrors.FromDB(err)
+	}
+	return rows, nil
+}
+
+func (r *StatsRepository) GetRecentlyModifiedUsers(ctx context.Context, limit int) ([]models.User, error) {
+	var users []models.User
+	err := r.reader(ctx).WithContext(ctx).Model(&models.User{}).Scopes(tenant.Scope(ctx), ownership.Scope(ctx)).
+		Order("updated_at DESC").
+		Limit(limit).
+		Find(&users).Error
+	if err != nil {
+		logs.Log.Error("Son değiştirilen kullanıcılar alınırken DB hatası", zap.Error(err))
+		return nil, domainerrors.FromDB(err)
+	}
+	return users, nil
+}
+
+func (r *StatsRepository) reader(ctx context.Context) *gorm.DB {
+	return replica.Reader(ctx, r.db)
+}
+
+func (r *StatsRepository) bucketExpression(column, bucket string) (string, error) {
+	if r.db.Dialector.Name() == "sqlite" {
+		switch bucket {
+		case BucketDay:
+			return fmt.Sprintf("date(%s)", column), nil
+		case BucketWeek:
+			return fmt.Sprintf("date(%s, 'weekday 0', '-6 days')", column), nil
+		case BucketMonth:
+			return fmt.Sprintf("strftime('%%Y-%%m-01', %s)", column), nil
+		}
+	} else {
+		switch bucket {
+		case BucketDay, BucketWeek, BucketMonth:
+			return fmt.Sprintf("to_char(date_trunc('%s', %s), 'YYYY-MM-DD')", bucket, column), nil
+		}
+	}
+	return "", domainerrors.Validation(fmt.Sprintf("geçersiz zaman aralığı: %s", bucket)).WithField("bucket")
+}
+
+var _ IStatsRepository = (*StatsRepository)(nil)
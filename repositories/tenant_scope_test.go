@@ -0,0 +1,40 @@
+package repositories_test
+
+import (
+	"testing"
+
+	"zatrano/database/testdb"
+	"zatrano/models"
+	"zatrano/pkg/domainerrors"
+	"zatrano/repositories"
+)
+
+func TestUpdateIsScopedToOrganization(t *testing.T) {
+	ctx := testdb.Organization(t)
+	user := testdb.User(t, ctx, models.User{Account: "scoped@test"})
+	other := testdb.As(testdb.Organization(t), user.ID)
+
+	err := repositories.NewUserRepository().Update(other, user.ID, user.Version, map[string]interface{}{"name": "Başka"}, user.ID)
+	if !domainerrors.IsNotFound(err) {
+		t.Fatalf("başka organizasyondaki kayıt bulunamamalı, dönen: %v", err)
+	}
+}
+
+func TestListIsScopedToOrganization(t *testing.T) {
+	ctx := testdb.Organization(t)
+	user := testdb.User(t, ctx, models.User{Account: "listed@test"})
+	other := testdb.Organization(t)
+	testdb.User(t, other, models.User{Account: "hidden@test"})
+
+	repo := repositories.NewUserRepository()
+	if _, err := repo.GetByID(other, user.ID); !domainerrors.IsNotFound(err) {
+		t.Fatalf("başka organizasyondaki kayıt okunamamalı, dönen: %v", err)
+	}
+	count, err := repo.GetCount(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Fatalf("yalnızca kendi organizasyonundaki 1 kullanıcı sayılmalı, dönen: %d", count)
+	}
+}
@@ -0,0 +1,28 @@
+package repositories_test
+
+import (
+	"testing"
+
+	"zatrano/configs"
+	"zatrano/database/testdb"
+	"zatrano/models"
+	"zatrano/repositories"
+)
+
+func TestLockActiveIDsByTypeSkipsInactiveUsers(t *testing.T) {
+	ctx := testdb.Organization(t)
+	active := testdb.User(t, ctx, models.User{Account: "active@test", Type: models.Dashboard})
+	inactive := testdb.User(t, ctx, models.User{Account: "inactive@test", Type: models.Dashboard})
+	testdb.User(t, ctx, models.User{Account: "panel@test"})
+	if err := configs.GetDB().WithContext(testdb.As(ctx, active.ID)).Model(inactive).Update("status", false).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	ids, err := repositories.NewUserRepository().LockActiveIDsByType(ctx, models.Dashboard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 1 || ids[0] != active.ID {
+		t.Fatalf("yalnızca %d bekleniyordu, dönen: %v", active.ID, ids)
+	}
+}
@@ -0,0 +1,51 @@
+package repositories_test
+
+import (
+	"testing"
+
+	"zatrano/configs"
+	"zatrano/database/testdb"
+	"zatrano/models"
+	"zatrano/repositories"
+)
+
+func TestUnassignAgentsRecordsAuditPerAgent(t *testing.T) {
+	ctx := testdb.Organization(t)
+	manager := testdb.User(t, ctx, models.User{Account: "manager@test", Type: models.Dashboard})
+	first := testdb.User(t, ctx, models.User{Account: "agent1@test", ManagerID: &manager.ID})
+	second := testdb.User(t, ctx, models.User{Account: "agent2@test", ManagerID: &manager.ID})
+
+	repo := repositories.NewUserRepository()
+	if err := repo.UnassignAgents(testdb.As(ctx, manager.ID), manager.ID); err != nil {
+		t.Fatalf("aracılar serbest bırakılamadı: %v", err)
+	}
+
+	for _, agent := range []*models.User{first, second} {
+		current, err := repo.GetByID(ctx, agent.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if current.ManagerID != nil {
+			t.Errorf("%s hâlâ bir yöneticiye bağlı", agent.Account)
+		}
+		if current.Version != agent.Version+1 {
+			t.Errorf("%s sürümü artırılmadı: %d", agent.Account, current.Version)
+		}
+
+		var entries []models.AuditLog
+		err = configs.GetDB().Where("entity_type = ? AND entity_id = ?", "users", agent.ID).Find(&entries).Error
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(entries) != 1 {
+			t.Fatalf("%s için 1 denetim kaydı bekleniyordu, bulunan: %d", agent.Account, len(entries))
+		}
+		change, ok := entries[0].Changes["manager_id"]
+		if !ok || change.New != nil {
+			t.Fatalf("manager_id değişikliği kaydedilmedi: %+v", entries[0].Changes)
+		}
+		if entries[0].ActorID == nil || *entries[0].ActorID != manager.ID {
+			t.Errorf("denetim kaydında işlemi yapan kullanıcı eksik: %v", entries[0].ActorID)
+		}
+	}
+}
@@ -2,14 +2,14 @@ package repositories
 
 import (
 	"context"
-	"errors"
-	"strings"
 
 	"zatrano/configs"
 	"zatrano/models"
+	"zatrano/pkg/audit"
+	"zatrano/pkg/domainerrors"
 	"zatrano/pkg/logs"
+	"zatrano/pkg/ownership"
 	"zatrano/pkg/queryparams"
-	"zatrano/pkg/turkishsearch"
 
 	"go.uber.org/zap"
 	"gorm.io/gorm"
@@ -17,164 +17,158 @@ import (
 )
 
 type IUserRepository interface {
-	GetAll(params queryparams.ListParams) ([]models.User, int64, error)
-	GetByID(id uint) (*models.User, error)
-	GetCount() (int64, error)
+	GetAll(ctx context.Context, params queryparams.ListParams, scopes ...Scope) ([]models.User, int64, error)
+	GetAllCursor(ctx context.Context, params queryparams.ListParams, scopes ...Scope) ([]models.User, *queryparams.CursorMeta, error)
+	GetByID(ctx context.Context, id uint) (*models.User, error)
+	GetCount(ctx context.Context) (int64, error)
+	LockActiveIDsByType(ctx context.Context, userType models.UserType) ([]uint, error)
+	GetManagers(ctx context.Context) ([]models.User, error)
+	ReassignManager(ctx context.Context, userIDs []uint, managerID *uint, updatedByID uint) (int64, error)
+	UnassignAgents(ctx context.Context, managerID uint) error
+	Transaction(ctx context.Context, fn func(repo IUserRepository) error) error
 	Create(ctx context.Context, user *models.User) error
-	Update(ctx context.Context, id uint, data map[string]interface{}, updatedByID uint) error
+	Update(ctx context.Context, id uint, version uint, data map[string]interface{}, updatedByID uint) error
 	Delete(ctx context.Context, id uint) error
 }
 
 type UserRepository struct {
-	db *gorm.DB
+	*Repository[models.User]
 }
 
 func NewUserRepository() IUserRepository {
-	return &UserRepository{db: configs.GetDB()}
+	repo := NewRepository[models.User](configs.GetDB())
+	repo.AddScope(ownership.Scope)
+	return &UserRepository{Repository: repo}
 }
 
-func (r *UserRepository) GetAll(params queryparams.ListParams) ([]models.User, int64, error) {
-	var users []models.User
-	var totalCount int64
-
-	query := r.db.Model(&models.User{})
-
-	if params.Name != "" {
-		sqlQueryFragment, queryParams := turkishsearch.SQLFilter("name", params.Name)
-		query = query.Where(sqlQueryFragment, queryParams...)
-	}
-
-	err := query.Count(&totalCount).Error
-	if err != nil {
-		logs.Log.Error("Kullanıcı sayısı alınırken hata (GelAll)", zap.Error(err))
-		return nil, 0, err
-	}
-
-	if totalCount == 0 {
-		return users, 0, nil
+func UsersManagedBy(managerID uint) Scope {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("users.manager_id = ?", managerID)
 	}
+}
 
-	sortBy := params.SortBy
-	orderBy := strings.ToLower(params.OrderBy)
-	if orderBy != "asc" && orderBy != "desc" {
-		orderBy = queryparams.DefaultOrderBy
-	}
-	allowedSortColumns := map[string]bool{"id": true, "name": true, "account": true, "created_at": true, "status": true, "type": true}
-	if _, ok := allowedSortColumns[sortBy]; !ok {
-		sortBy = queryparams.DefaultSortBy
+func UsersWithoutManager() Scope {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("users.type = ? AND users.manager_id IS NULL", models.Panel)
 	}
-	orderClause := sortBy + " " + orderBy
-	query = query.Order(orderClause)
-
-	query = query.Preload(clause.Associations)
-
-	offset := params.CalculateOffset()
-	query = query.Limit(params.PerPage).Offset(offset)
+}
 
-	err = query.Find(&users).Error
-	if err != nil {
-		logs.Log.Error("Kullanıcı verisi çekilirken hata (GelAll)", zap.Error(err))
-		return nil, totalCount, err
+func (r *UserRepository) LockActiveIDsByType(ctx context.Context, userType models.UserType) ([]uint, error) {
+	query := r.scoped(ctx, r.db).Model(&models.User{}).Where("type = ? AND status = ?", userType, true).Order("id")
+	if r.db.Dialector.Name() == "postgres" {
+		query = query.Clauses(clause.Locking{Strength: "UPDATE"})
 	}
 
-	return users, totalCount, nil
-}
-
-func (r *UserRepository) GetByID(id uint) (*models.User, error) {
-	var user models.User
-	err := r.db.Preload(clause.Associations).First(&user, id).Error
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("kayıt bulunamadı")
-		}
-		logs.Log.Error("GetByID sırasında DB hatası", zap.Uint("user_id", id), zap.Error(err))
-		return nil, err
+	var ids []uint
+	if err := query.Pluck("id", &ids).Error; err != nil {
+		logs.Log.Error("Aktif kullanıcılar kilitlenirken DB hatası", zap.String("type", string(userType)), zap.Error(err))
+		return nil, domainerrors.FromDB(err)
 	}
-	return &user, nil
+	return ids, nil
 }
 
-func (r *UserRepository) GetCount() (int64, error) {
-	var count int64
-	err := r.db.Model(&models.User{}).Count(&count).Error
+func (r *UserRepository) GetManagers(ctx context.Context) ([]models.User, error) {
+	var managers []models.User
+	err := r.scoped(ctx, r.db).Where("type = ?", models.Dashboard).Order("name").Find(&managers).Error
 	if err != nil {
-		logs.Log.Error("Count sırasında DB hatası", zap.Error(err))
+		logs.Log.Error("Yönetici listesi alınırken DB hatası", zap.Error(err))
+		return nil, domainerrors.FromDB(err)
 	}
-	return count, err
+	return managers, nil
 }
 
-func (r *UserRepository) Create(ctx context.Context, user *models.User) error {
-	result := r.db.WithContext(ctx).Create(user)
-	if result.Error != nil {
-		logs.Log.Error("Create sırasında DB hatası", zap.Any("user_account", user.Account), zap.Error(result.Error))
-	}
-	return result.Error
-}
+func (r *UserRepository) ReassignManager(ctx context.Context, userIDs []uint, managerID *uint, updatedByID uint) (int64, error) {
+	var affected int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var before []models.User
+		err := r.scoped(ctx, tx).Where("id IN ? AND type = ?", userIDs, models.Panel).Order("id").Find(&before).Error
+		if err != nil {
+			logs.Log.Error("Sahibi değiştirilecek kullanıcılar alınırken DB hatası", zap.Error(err))
+			return domainerrors.FromDB(err)
+		}
 
-func (r *UserRepository) Update(ctx context.Context, id uint, data map[string]interface{}, updatedByID uint) error {
-	if len(data) == 0 {
-		logs.Log.Debug("UserRepository.Update: Güncellenecek veri yok.", zap.Uint("user_id", id))
+		for i := range before {
+			previous := before[i]
+			if sameManager(previous.ManagerID, managerID) {
+				continue
+			}
+
+			result := tx.Model(&models.User{}).Where("id = ?", previous.ID).Updates(map[string]interface{}{
+				"manager_id": managerID,
+				"updated_by": updatedByID,
+				"version":    gorm.Expr("version + 1"),
+			})
+			if result.Error != nil {
+				logs.Log.Error("Kullanıcının sahibi güncellenirken DB hatası", zap.Uint("user_id", previous.ID), zap.Error(result.Error))
+				return r.translate(result.Error)
+			}
+
+			var after models.User
+			if err := tx.First(&after, previous.ID).Error; err != nil {
+				logs.Log.Error("Sahip değişikliği sonrası kullanıcı okunamadı", zap.Uint("user_id", previous.ID), zap.Error(err))
+				return domainerrors.FromDB(err)
+			}
+			if err := r.recordAudit(ctx, tx, audit.ActionUpdate, previous.ID, &previous, &after); err != nil {
+				return err
+			}
+			affected++
+		}
 		return nil
+	})
+	if err != nil {
+		return 0, err
 	}
-
-	if updatedByID != 0 {
-		data["updated_by"] = updatedByID
-	} else {
-		logs.Log.Warn("UserRepository.Update: Geçersiz updatedByID (0) alındı, updated_by alanı ayarlanamadı.",
-			zap.Uint("target_user_id", id))
-	}
-
-	result := r.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", id).Updates(data)
-
-	if result.Error != nil {
-		logs.Log.Error("Update sırasında DB hatası", zap.Uint("user_id", id), zap.Error(result.Error))
-		return result.Error
-	}
-
-	if result.RowsAffected == 0 {
-		logs.Log.Warn("UserRepository.Update: Kayıt bulunamadı veya hiçbir alan değişmedi.",
-			zap.Uint("user_id", id),
-			zap.Int64("rows_affected", result.RowsAffected))
-		return errors.New("kayıt bulunamadı")
-	}
-
-	return nil
+	return affected, nil
 }
 
-func (r *UserRepository) Delete(ctx context.Context, id uint) error {
-	var user models.User
-
-	findTx := r.db.WithContext(ctx).First(&user, id)
-	if findTx.Error != nil {
-		if errors.Is(findTx.Error, gorm.ErrRecordNotFound) {
-			logs.Log.Warn("UserRepository.Delete: Silinecek kullanıcı bulunamadı", zap.Uint("user_id", id))
-			return errors.New("kayıt bulunamadı")
+func (r *UserRepository) UnassignAgents(ctx context.Context, managerID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var agentIDs []uint
+		if err := r.scoped(ctx, tx).Model(&models.User{}).Where("manager_id = ?", managerID).Order("id").Pluck("id", &agentIDs).Error; err != nil {
+			logs.Log.Error("Yöneticinin aracıları alınırken DB hatası", zap.Uint("manager_id", managerID), zap.Error(err))
+			return domainerrors.FromDB(err)
+		}
+		if len(agentIDs) == 0 {
+			return nil
 		}
-		logs.Log.Error("Delete sırasında kullanıcı bulunurken DB hatası", zap.Uint("user_id", id), zap.Error(findTx.Error))
-		return findTx.Error
-	}
 
-	userID, ok := ctx.Value("user_id").(uint)
-	if !ok || userID == 0 {
-		return errors.New("Delete: Context içinde user_id yok veya geçersiz")
-	}
+		data := map[string]interface{}{"manager_id": nil, "version": gorm.Expr("version + 1")}
+		if userID, ok := ctx.Value("user_id").(uint); ok && userID != 0 {
+			data["updated_by"] = userID
+		}
+		if err := tx.Model(&models.User{}).Where("id IN ?", agentIDs).Updates(data).Error; err != nil {
+			logs.Log.Error("Silinen yöneticinin aracıları serbest bırakılırken DB hatası", zap.Uint("manager_id", managerID), zap.Error(err))
+			return domainerrors.FromDB(err)
+		}
 
-	updateTx := r.db.WithContext(ctx).Model(&user).Update("deleted_by", userID)
-	if updateTx.Error != nil {
-		logs.Log.Error("deleted_by güncellenirken hata", zap.Error(updateTx.Error))
-		return updateTx.Error
-	}
+		for _, agentID := range agentIDs {
+			err := audit.Record(ctx, tx, audit.Entry{
+				Action:     audit.ActionUpdate,
+				EntityType: r.table,
+				EntityID:   agentID,
+				Changes:    models.AuditChanges{"manager_id": {Old: managerID, New: nil}},
+			})
+			if err != nil {
+				logs.Log.Error("Aracı sahip değişikliği denetim kaydı yazılamadı", zap.Uint("user_id", agentID), zap.Error(err))
+				return domainerrors.Internal("denetim kaydı oluşturulamadı", err)
+			}
+		}
+		logs.Log.Info("Yöneticinin aracıları serbest bırakıldı", zap.Uint("manager_id", managerID), zap.Int("count", len(agentIDs)))
+		return nil
+	})
+}
 
-	deleteTx := r.db.WithContext(ctx).Delete(&user)
-	if deleteTx.Error != nil {
-		logs.Log.Error("Delete sırasında DB hatası", zap.Uint("user_id", user.ID), zap.Error(deleteTx.Error))
-		return deleteTx.Error
-	}
+func (r *UserRepository) Transaction(ctx context.Context, fn func(repo IUserRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&UserRepository{Repository: r.withTx(tx)})
+	})
+}
 
-	if deleteTx.RowsAffected == 0 {
-		logs.Log.Warn("UserRepository.Delete: Silme işlemi 0 satırı etkiledi", zap.Uint("user_id", user.ID))
+func sameManager(current, next *uint) bool {
+	if current == nil || next == nil {
+		return current == nil && next == nil
 	}
-
-	return nil
+	return *current == *next
 }
 
 var _ IUserRepository = (*UserRepository)(nil)
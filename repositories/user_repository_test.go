@@ -0,0 +1,52 @@
+package repositories_test
+
+import (
+	"errors"
+	"testing"
+
+	"zatrano/database/testdb"
+	"zatrano/models"
+	"zatrano/repositories"
+)
+
+func TestUpdateRequiresCurrentVersion(t *testing.T) {
+	ctx := testdb.Organization(t)
+	user := testdb.User(t, ctx, models.User{Account: "versioned@test"})
+	ctx = testdb.As(ctx, user.ID)
+	repo := repositories.NewUserRepository()
+
+	err := repo.Update(ctx, user.ID, 0, map[string]interface{}{"name": "Sürümsüz"}, user.ID)
+	if !errors.Is(err, repositories.ErrVersionRequired) {
+		t.Fatalf("sürümsüz güncelleme ErrVersionRequired dönmeli, dönen: %v", err)
+	}
+
+	if err := repo.Update(ctx, user.ID, user.Version, map[string]interface{}{"name": "Yeni Ad"}, user.ID); err != nil {
+		t.Fatalf("güncel sürümle güncelleme başarısız: %v", err)
+	}
+	updated, err := repo.GetByID(ctx, user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Name != "Yeni Ad" || updated.Version != user.Version+1 {
+		t.Fatalf("beklenen ad/sürüm Yeni Ad/%d, dönen %s/%d", user.Version+1, updated.Name, updated.Version)
+	}
+
+	err = repo.Update(ctx, user.ID, user.Version, map[string]interface{}{"name": "Eski Sürüm"}, user.ID)
+	if !errors.Is(err, repositories.ErrVersionConflict) {
+		t.Fatalf("eski sürümle güncelleme ErrVersionConflict dönmeli, dönen: %v", err)
+	}
+}
+
+func TestUpdateLeavesCallerDataUntouched(t *testing.T) {
+	ctx := testdb.Organization(t)
+	user := testdb.User(t, ctx, models.User{Account: "untouched@test"})
+	ctx = testdb.As(ctx, user.ID)
+
+	data := map[string]interface{}{"name": "Yeni Ad"}
+	if err := repositories.NewUserRepository().Update(ctx, user.ID, user.Version, data, user.ID); err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != 1 || data["name"] != "Yeni Ad" {
+		t.Fatalf("güncelleme verisi değiştirilmemeli, dönen: %v", data)
+	}
+}
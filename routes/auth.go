@@ -16,6 +16,8 @@ func registerAuthRoutes(app *fiber.App) {
 	authGroup.Post("/login", middlewares.GuestMiddleware, authHandler.Login)
 
 	authGroup.Get("/logout", middlewares.AuthMiddleware, authHandler.Logout)
-	authGroup.Get("/profile", middlewares.AuthMiddleware, authHandler.Profile)
-	authGroup.Post("/profile/update-password", middlewares.AuthMiddleware, authHandler.UpdatePassword)
+	authGroup.Get("/profile", middlewares.AuthMiddleware, middlewares.PasswordChangeMiddleware, authHandler.Profile)
+	authGroup.Post("/profile/update-password", middlewares.AuthMiddleware, middlewares.PasswordChangeMiddleware, authHandler.UpdatePassword)
+	authGroup.Get("/change-password", middlewares.AuthMiddleware, authHandler.ShowChangePassword)
+	authGroup.Post("/change-password", middlewares.AuthMiddleware, authHandler.UpdatePassword)
 }
@@ -2,6 +2,7 @@ package routes
 
 import (
 	handlers "zatrano/handlers/dashboard"
+	notificationHandlers "zatrano/handlers/notification"
 	"zatrano/middlewares"
 	"zatrano/models"
 
@@ -12,13 +13,21 @@ func registerDashboardRoutes(app *fiber.App) {
 	dashboardGroup := app.Group("/dashboard")
 	dashboardGroup.Use(
 		middlewares.AuthMiddleware,
+		middlewares.PasswordChangeMiddleware,
 		middlewares.StatusMiddleware,
 		middlewares.TypeMiddleware(models.Dashboard),
+		middlewares.NotificationMiddleware,
 	)
 
 	dashboardHomeHandler := handlers.NewDashboardHomeHandler()
 	dashboardGroup.Get("/home", dashboardHomeHandler.HomePage)
 
+	statsHandler := handlers.NewDashboardStatsHandler()
+	dashboardGroup.Get("/stats/users/breakdown", statsHandler.UserBreakdown)
+	dashboardGroup.Get("/stats/users/registrations", statsHandler.UserRegistrations)
+	dashboardGroup.Get("/stats/users/recent", statsHandler.RecentUsers)
+	dashboardGroup.Get("/stats/logins", statsHandler.LoginActivity)
+
 	userHandler := handlers.NewUserHandler()
 	dashboardGroup.Get("/users", userHandler.ListUsers)
 	dashboardGroup.Get("/users/create", userHandler.ShowCreateUser)
@@ -26,4 +35,28 @@ func registerDashboardRoutes(app *fiber.App) {
 	dashboardGroup.Get("/users/update/:id", userHandler.ShowUpdateUser)
 	dashboardGroup.Post("/users/update/:id", userHandler.UpdateUser)
 	dashboardGroup.Delete("/users/delete/:id", userHandler.DeleteUser)
+	dashboardGroup.Post("/users/reassign", middlewares.FullAccessMiddleware, userHandler.ReassignUsers)
+
+	notificationHandler := notificationHandlers.NewNotificationHandler("/dashboard/notifications", "layouts/dashboard")
+	dashboardGroup.Get("/notifications", notificationHandler.ListNotifications)
+	dashboardGroup.Post("/notifications/read-all", notificationHandler.MarkAllRead)
+	dashboardGroup.Post("/notifications/:id/read", notificationHandler.MarkRead)
+
+	auditHandler := handlers.NewAuditHandler()
+	dashboardGroup.Get("/audit", middlewares.FullAccessMiddleware, auditHandler.ListAuditLogs)
+
+	settingHandler := handlers.NewSettingHandler()
+	dashboardGroup.Get("/settings", middlewares.SuperAdminMiddleware, settingHandler.ShowSettings)
+	dashboardGroup.Post("/settings", middlewares.SuperAdminMiddleware, settingHandler.UpdateSettings)
+
+	jobHandler := handlers.NewJobHandler()
+	dashboardGroup.Get("/jobs", middlewares.SuperAdminMiddleware, jobHandler.ListJobs)
+	dashboardGroup.Post("/jobs/:id/retry", middlewares.SuperAdminMiddleware, jobHandler.RetryJob)
+
+	organizationHandler := handlers.NewOrganizationHandler()
+	organizationGroup := dashboardGroup.Group("/organizations", middlewares.SuperAdminMiddleware)
+	organizationGroup.Get("/", organizationHandler.ListOrganizations)
+	organizationGroup.Post("/", organizationHandler.CreateOrganization)
+	organizationGroup.Post("/:id/status", organizationHandler.UpdateOrganizationStatus)
+	organizationGroup.Post("/:id/switch", organizationHandler.SwitchOrganization)
 }
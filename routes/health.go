@@ -0,0 +1,15 @@
+package routes
+
+import (
+	handlers "zatrano/handlers/health"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func registerHealthRoutes(app *fiber.App) {
+	healthHandler := handlers.NewHealthHandler()
+
+	healthGroup := app.Group("/health")
+	healthGroup.Get("/live", healthHandler.Live)
+	healthGroup.Get("/ready", healthHandler.Ready)
+}
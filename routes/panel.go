@@ -1,6 +1,7 @@
 package routes
 
 import (
+	notificationHandlers "zatrano/handlers/notification"
 	handlers "zatrano/handlers/panel"
 	"zatrano/middlewares"
 	"zatrano/models"
@@ -12,9 +13,17 @@ func registerPanelRoutes(app *fiber.App) {
 	panelGroup := app.Group("/panel")
 	panelGroup.Use(
 		middlewares.AuthMiddleware,
+		middlewares.PasswordChangeMiddleware,
 		middlewares.StatusMiddleware,
 		middlewares.TypeMiddleware(models.Panel),
+		middlewares.NotificationMiddleware,
 	)
 
-	panelGroup.Get("/home", handlers.PanelHomeHandler)
+	panelHomeHandler := handlers.NewPanelHomeHandler()
+	panelGroup.Get("/home", panelHomeHandler.HomePage)
+
+	notificationHandler := notificationHandlers.NewNotificationHandler("/panel/notifications", "layouts/panel")
+	panelGroup.Get("/notifications", notificationHandler.ListNotifications)
+	panelGroup.Post("/notifications/read-all", notificationHandler.MarkAllRead)
+	panelGroup.Post("/notifications/:id/read", notificationHandler.MarkRead)
 }
@@ -2,23 +2,31 @@ package routes
 
 import (
 	"zatrano/configs"
+	"zatrano/middlewares"
 	"zatrano/models"
 	"zatrano/pkg/sessions"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/logger"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
 	"gorm.io/gorm"
 )
 
 func SetupRoutes(app *fiber.App, db *gorm.DB) {
+	app.Use(requestid.New())
 	app.Use(logger.New())
 
+	registerHealthRoutes(app)
+
 	sessionStore := configs.SetupSession()
 	app.Use(func(c *fiber.Ctx) error {
 		c.Locals("session", sessionStore)
 		return c.Next()
 	})
 
+	app.Use(middlewares.DatabaseAvailabilityMiddleware)
+	app.Use(middlewares.ReplicaRoutingMiddleware)
+
 	registerAuthRoutes(app)
 	registerDashboardRoutes(app)
 	registerPanelRoutes(app)
@@ -0,0 +1,193 @@
+package services
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"time"
+
+	"zatrano/models"
+	"zatrano/pkg/audit"
+	"zatrano/pkg/domainerrors"
+	"zatrano/pkg/logs"
+	"zatrano/pkg/queryparams"
+	"zatrano/pkg/validation"
+	"zatrano/repositories"
+
+	"go.uber.org/zap"
+)
+
+const DefaultEntityAuditLimit = 20
+
+var AuditActions = []string{audit.ActionCreate, audit.ActionUpdate, audit.ActionDelete}
+
+type AuditQuery struct {
+	ActorID    uint   `query:"actor_id"`
+	Action     string `query:"action"`
+	EntityType string `query:"entity_type"`
+	EntityID   uint   `query:"entity_id"`
+	RequestID  string `query:"request_id"`
+	From       string `query:"from"`
+	To         string `query:"to"`
+	Page       int    `query:"page"`
+	PerPage    int    `query:"perPage"`
+}
+
+func (q AuditQuery) Encode() string {
+	values := url.Values{}
+	if q.ActorID != 0 {
+		values.Set("actor_id", strconv.FormatUint(uint64(q.ActorID), 10))
+	}
+	if q.Action != "" {
+		values.Set("action", q.Action)
+	}
+	if q.EntityType != "" {
+		values.Set("entity_type", q.EntityType)
+	}
+	if q.EntityID != 0 {
+		values.Set("entity_id", strconv.FormatUint(uint64(q.EntityID), 10))
+	}
+	if q.RequestID != "" {
+		values.Set("request_id", q.RequestID)
+	}
+	if q.From != "" {
+		values.Set("from", q.From)
+	}
+	if q.To != "" {
+		values.Set("to", q.To)
+	}
+	if q.PerPage != 0 {
+		values.Set("perPage", strconv.Itoa(q.PerPage))
+	}
+	return values.Encode()
+}
+
+func (q AuditQuery) IsFiltered() bool {
+	return q.ActorID != 0 || q.Action != "" || q.EntityType != "" || q.EntityID != 0 || q.RequestID != "" || q.From != "" || q.To != ""
+}
+
+type AuditEntry struct {
+	models.AuditLog
+	ActorName string
+}
+
+type AuditPage struct {
+	Entries []AuditEntry
+	Meta    queryparams.PaginationMeta
+}
+
+type IAuditService interface {
+	List(ctx context.Context, query AuditQuery) (*AuditPage, error)
+	ForEntity(ctx context.Context, entityType string, entityID uint, limit int) ([]AuditEntry, error)
+	GetEntityTypes(ctx context.Context) ([]string, error)
+}
+
+type AuditService struct {
+	repo repositories.IAuditRepository
+}
+
+func NewAuditService() IAuditService {
+	return &AuditService{repo: repositories.NewAuditRepository()}
+}
+
+func (s *AuditService) List(ctx context.Context, query AuditQuery) (*AuditPage, error) {
+	params := NormalizeListParams(queryparams.ListParams{Page: query.Page, PerPage: query.PerPage})
+
+	filter := repositories.AuditFilter{
+		ActorID:    query.ActorID,
+		Action:     query.Action,
+		EntityType: query.EntityType,
+		EntityID:   query.EntityID,
+		RequestID:  query.RequestID,
+	}
+	errs := validation.Errors{}
+	if query.From != "" {
+		from, err := time.ParseInLocation(validation.DateLayout, query.From, time.Local)
+		if err != nil {
+			errs["from"] = "başlangıç tarihi geçersiz"
+		} else {
+			filter.From = &from
+		}
+	}
+	if query.To != "" {
+		to, err := time.ParseInLocation(validation.DateLayout, query.To, time.Local)
+		if err != nil {
+			errs["to"] = "bitiş tarihi geçersiz"
+		} else {
+			to = to.AddDate(0, 0, 1)
+			filter.To = &to
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	rows, total, err := s.repo.Find(ctx, filter, params.CalculateOffset(), params.PerPage)
+	if err != nil {
+		return nil, s.internalError(err)
+	}
+	entries, err := s.withActorNames(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AuditPage{
+		Entries: entries,
+		Meta: queryparams.PaginationMeta{
+			CurrentPage: params.Page,
+			PerPage:     params.PerPage,
+			TotalItems:  total,
+			TotalPages:  queryparams.CalculateTotalPages(total, params.PerPage),
+		},
+	}, nil
+}
+
+func (s *AuditService) ForEntity(ctx context.Context, entityType string, entityID uint, limit int) ([]AuditEntry, error) {
+	if limit <= 0 {
+		limit = DefaultEntityAuditLimit
+	}
+	rows, _, err := s.repo.Find(ctx, repositories.AuditFilter{EntityType: entityType, EntityID: entityID}, 0, limit)
+	if err != nil {
+		return nil, s.internalError(err)
+	}
+	return s.withActorNames(rows)
+}
+
+func (s *AuditService) GetEntityTypes(ctx context.Context) ([]string, error) {
+	types, err := s.repo.GetEntityTypes(ctx)
+	if err != nil {
+		return nil, s.internalError(err)
+	}
+	return types, nil
+}
+
+func (s *AuditService) withActorNames(rows []models.AuditLog) ([]AuditEntry, error) {
+	seen := map[uint]bool{}
+	var ids []uint
+	for _, row := range rows {
+		if row.ActorID != nil && !seen[*row.ActorID] {
+			seen[*row.ActorID] = true
+			ids = append(ids, *row.ActorID)
+		}
+	}
+	names, err := s.repo.GetActorNames(ids)
+	if err != nil {
+		return nil, s.internalError(err)
+	}
+
+	entries := make([]AuditEntry, len(rows))
+	for i, row := range rows {
+		entries[i] = AuditEntry{AuditLog: row}
+		if row.ActorID != nil {
+			entries[i].ActorName = names[*row.ActorID]
+		}
+	}
+	return entries, nil
+}
+
+func (s *AuditService) internalError(err error) error {
+	logs.Log.Error("Denetim kayıtları alınamadı", zap.Error(err))
+	return domainerrors.Internal("denetim kayıtları getirilirken bir hata oluştu", err)
+}
+
+var _ IAuditService = (*AuditService)(nil)
@@ -1,13 +1,16 @@
 package services
 
 import (
+	"context"
+
 	"zatrano/models"
+	"zatrano/pkg/domainerrors"
 	"zatrano/pkg/logs"
+	"zatrano/pkg/replica"
 	"zatrano/repositories"
 
 	"go.uber.org/zap"
 	"golang.org/x/crypto/bcrypt"
-	"gorm.io/gorm"
 )
 
 type ServiceError string
@@ -21,8 +24,6 @@ const (
 	ErrUserNotFound             ServiceError = "kullanıcı bulunamadı"
 	ErrUserInactive             ServiceError = "kullanıcı aktif değil"
 	ErrCurrentPasswordIncorrect ServiceError = "mevcut şifre hatalı"
-	ErrPasswordTooShort         ServiceError = "yeni şifre en az 6 karakter olmalıdır"
-	ErrPasswordSameAsOld        ServiceError = "yeni şifre mevcut şifre ile aynı olamaz"
 	ErrAuthGeneric              ServiceError = "kimlik doğrulaması sırasında bir hata oluştu"
 	ErrProfileGeneric           ServiceError = "profil bilgileri alınırken hata"
 	ErrUpdatePasswordGeneric    ServiceError = "şifre güncellenirken bir hata oluştu"
@@ -32,8 +33,9 @@ const (
 
 type IAuthService interface {
 	Authenticate(account, password string) (*models.User, error)
-	GetUserProfile(id uint) (*models.User, error)
-	UpdatePassword(userID uint, currentPass, newPassword string) error
+	GetUserProfile(ctx context.Context, id uint) (*models.User, error)
+	UpdatePassword(ctx context.Context, userID uint, currentPass, newPassword string) error
+	RecordLogin(userID uint, ip, userAgent string) error
 }
 
 type AuthService struct {
@@ -47,7 +49,7 @@ func NewAuthService() IAuthService {
 func (s *AuthService) Authenticate(account, password string) (*models.User, error) {
 	user, err := s.repo.FindUserByAccount(account)
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
+		if domainerrors.IsNotFound(err) {
 			logs.Log.Warn("Kimlik doğrulama başarısız: Kullanıcı bulunamadı", zap.String("account", account))
 			return nil, ErrInvalidCredentials
 		}
@@ -82,10 +84,10 @@ func (s *AuthService) Authenticate(account, password string) (*models.User, erro
 	return user, nil
 }
 
-func (s *AuthService) GetUserProfile(id uint) (*models.User, error) {
-	user, err := s.repo.FindUserByID(id)
+func (s *AuthService) GetUserProfile(ctx context.Context, id uint) (*models.User, error) {
+	user, err := s.repo.FindUserByID(ctx, id)
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
+		if domainerrors.IsNotFound(err) {
 			logs.Log.Warn("Profil alınamadı: Kullanıcı bulunamadı", zap.Uint("user_id", id))
 			return nil, ErrUserNotFound
 		}
@@ -93,15 +95,18 @@ func (s *AuthService) GetUserProfile(id uint) (*models.User, error) {
 			zap.Uint("user_id", id),
 			zap.Error(err),
 		)
+		if domainerrors.IsUnavailable(err) {
+			return nil, err
+		}
 		return nil, ErrProfileGeneric
 	}
 	return user, nil
 }
 
-func (s *AuthService) UpdatePassword(userID uint, currentPass, newPassword string) error {
-	user, err := s.repo.FindUserByID(userID)
+func (s *AuthService) UpdatePassword(ctx context.Context, userID uint, currentPass, newPassword string) error {
+	user, err := s.repo.FindUserByID(replica.ForcePrimary(ctx), userID)
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
+		if domainerrors.IsNotFound(err) {
 			logs.Log.Warn("Parola güncelleme başarısız: Kullanıcı bulunamadı", zap.Uint("user_id", userID))
 			return ErrUserNotFound
 		}
@@ -117,15 +122,6 @@ func (s *AuthService) UpdatePassword(userID uint, currentPass, newPassword strin
 		return ErrCurrentPasswordIncorrect
 	}
 
-	if len(newPassword) < 6 {
-		logs.Log.Warn("Parola güncelleme başarısız: Yeni parola çok kısa", zap.Uint("user_id", userID))
-		return ErrPasswordTooShort
-	}
-	if currentPass == newPassword {
-		logs.Log.Warn("Parola güncelleme başarısız: Yeni parola eskiyle aynı", zap.Uint("user_id", userID))
-		return ErrPasswordSameAsOld
-	}
-
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
 	if err != nil {
 		logs.Log.Error("Parola güncelleme hatası: Yeni parola hashlenemedi",
@@ -135,8 +131,7 @@ func (s *AuthService) UpdatePassword(userID uint, currentPass, newPassword strin
 		return ErrHashingFailed
 	}
 
-	user.Password = string(hashedPassword)
-	if err := s.repo.UpdateUser(user); err != nil {
+	if err := s.repo.UpdatePassword(ctx, userID, string(hashedPassword)); err != nil {
 		logs.Log.Error("Parola güncelleme hatası: Kullanıcı güncellenirken DB hatası",
 			zap.Uint("user_id", userID),
 			zap.Error(err),
@@ -148,4 +143,20 @@ func (s *AuthService) UpdatePassword(userID uint, currentPass, newPassword strin
 	return nil
 }
 
+func (s *AuthService) RecordLogin(userID uint, ip, userAgent string) error {
+	if len(userAgent) > 255 {
+		userAgent = userAgent[:255]
+	}
+	event := &models.LoginEvent{
+		UserID:    userID,
+		IP:        ip,
+		UserAgent: userAgent,
+	}
+	if err := s.repo.CreateLoginEvent(event); err != nil {
+		logs.Log.Error("Giriş kaydı oluşturulamadı", zap.Uint("user_id", userID), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
 var _ IAuthService = (*AuthService)(nil)
@@ -0,0 +1,64 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	"zatrano/configs"
+	"zatrano/database/testdb"
+	"zatrano/models"
+	"zatrano/pkg/redact"
+	"zatrano/services"
+)
+
+func TestUpdatePasswordBumpsVersion(t *testing.T) {
+	ctx := testdb.Organization(t)
+	user := testdb.User(t, ctx, models.User{Account: "password@test", Password: "oldsecret1"})
+	ctx = testdb.As(ctx, user.ID)
+	auth := services.NewAuthService()
+
+	if err := auth.UpdatePassword(ctx, user.ID, "wrong", "newsecret1"); !errors.Is(err, services.ErrCurrentPasswordIncorrect) {
+		t.Fatalf("hatalı mevcut şifre reddedilmeli, dönen: %v", err)
+	}
+	if err := auth.UpdatePassword(ctx, user.ID, "oldsecret1", "newsecret1"); err != nil {
+		t.Fatalf("şifre güncellenemedi: %v", err)
+	}
+
+	updated, err := auth.GetUserProfile(ctx, user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := updated.CheckPassword("newsecret1"); err != nil {
+		t.Fatal("yeni şifre kaydedilmedi")
+	}
+	if updated.Version != user.Version+1 {
+		t.Fatalf("şifre değişikliği sürümü artırmalı: %d -> %d", user.Version, updated.Version)
+	}
+}
+
+func TestUpdatePasswordRecordsAudit(t *testing.T) {
+	ctx := testdb.Organization(t)
+	user := testdb.User(t, ctx, models.User{Account: "audited@test", Password: "oldsecret1", MustChangePassword: true})
+
+	if err := services.NewAuthService().UpdatePassword(testdb.As(ctx, user.ID), user.ID, "oldsecret1", "newsecret1"); err != nil {
+		t.Fatalf("şifre güncellenemedi: %v", err)
+	}
+
+	var entries []models.AuditLog
+	if err := configs.GetDB().Where("entity_type = ? AND entity_id = ?", "users", user.ID).Find(&entries).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("1 denetim kaydı bekleniyordu, bulunan: %d", len(entries))
+	}
+	entry := entries[0]
+	if entry.ActorID == nil || *entry.ActorID != user.ID || entry.OrganizationID == nil || *entry.OrganizationID != user.OrganizationID {
+		t.Fatalf("denetim kaydının kullanıcısı veya organizasyonu hatalı: %+v", entry)
+	}
+	if change := entry.Changes["password"]; change.Old != redact.Value || change.New != redact.Value {
+		t.Fatalf("şifre gizlenmeliydi: %+v", change)
+	}
+	if change, ok := entry.Changes["must_change_password"]; !ok || change.Old != true || change.New != false {
+		t.Fatalf("zorunlu şifre değişikliği kaydedilmedi: %+v", entry.Changes)
+	}
+}
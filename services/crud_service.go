@@ -0,0 +1,167 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"zatrano/pkg/domainerrors"
+	"zatrano/pkg/logs"
+	"zatrano/pkg/queryparams"
+	"zatrano/pkg/settings"
+	"zatrano/repositories"
+
+	"go.uber.org/zap"
+)
+
+type ICRUDService[T any] interface {
+	GetAll(ctx context.Context, params queryparams.ListParams, scopes ...repositories.Scope) (*queryparams.PaginatedResult, error)
+	GetByID(ctx context.Context, id uint) (*T, error)
+	GetCount(ctx context.Context) (int64, error)
+	Create(ctx context.Context, entity *T) error
+	Update(ctx context.Context, id uint, version uint, data map[string]interface{}) error
+	Delete(ctx context.Context, id uint) error
+}
+
+type CRUDService[T any] struct {
+	repo  repositories.IRepository[T]
+	label string
+}
+
+func NewCRUDService[T any](repo repositories.IRepository[T], label string) *CRUDService[T] {
+	return &CRUDService[T]{repo: repo, label: label}
+}
+
+func (s *CRUDService[T]) GetAll(ctx context.Context, params queryparams.ListParams, scopes ...repositories.Scope) (*queryparams.PaginatedResult, error) {
+	params = NormalizeListParams(params)
+
+	if params.IsCursorMode() {
+		params.Mode = queryparams.CursorMode
+		rows, cursorMeta, err := s.repo.GetAllCursor(ctx, params, scopes...)
+		if err != nil {
+			logs.Log.Error("CRUDService.GetAll: Repository hatası (cursor)", zap.String("entity", s.label), zap.Error(err))
+			return nil, domainerrors.Internal(fmt.Sprintf("%s listesi getirilirken bir hata oluştu", s.label), err)
+		}
+		return &queryparams.PaginatedResult{
+			Data: rows,
+			Meta: queryparams.PaginationMeta{
+				PerPage:    params.PerPage,
+				TotalItems: cursorMeta.TotalItems,
+			},
+			Cursor: cursorMeta,
+		}, nil
+	}
+
+	rows, totalCount, err := s.repo.GetAll(ctx, params, scopes...)
+	if err != nil {
+		logs.Log.Error("CRUDService.GetAll: Repository hatası", zap.String("entity", s.label), zap.Error(err))
+		return nil, domainerrors.Internal(fmt.Sprintf("%s listesi getirilirken bir hata oluştu", s.label), err)
+	}
+
+	return &queryparams.PaginatedResult{
+		Data: rows,
+		Meta: queryparams.PaginationMeta{
+			CurrentPage: params.Page,
+			PerPage:     params.PerPage,
+			TotalItems:  totalCount,
+			TotalPages:  queryparams.CalculateTotalPages(totalCount, params.PerPage),
+		},
+	}, nil
+}
+
+func (s *CRUDService[T]) GetByID(ctx context.Context, id uint) (*T, error) {
+	entity, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if domainerrors.IsNotFound(err) {
+			logs.Log.Warn("Kayıt bulunamadı (ID ile arama)", zap.String("entity", s.label), zap.Uint("id", id))
+			return nil, domainerrors.WithMessage(err, fmt.Sprintf("%s bulunamadı", s.label))
+		}
+		logs.Log.Error("Kayıt alınırken hata oluştu (ID ile arama)", zap.String("entity", s.label), zap.Uint("id", id), zap.Error(err))
+		return nil, domainerrors.Internal(fmt.Sprintf("%s bilgileri alınırken bir veritabanı hatası oluştu", s.label), err)
+	}
+	return entity, nil
+}
+
+func (s *CRUDService[T]) GetCount(ctx context.Context) (int64, error) {
+	count, err := s.repo.GetCount(ctx)
+	if err != nil {
+		logs.Log.Error("Kayıt sayısı alınırken hata oluştu", zap.String("entity", s.label), zap.Error(err))
+		return 0, domainerrors.Internal(fmt.Sprintf("%s sayısı alınırken bir hata oluştu", s.label), err)
+	}
+	return count, nil
+}
+
+func (s *CRUDService[T]) Create(ctx context.Context, entity *T) error {
+	if err := s.repo.Create(ctx, entity); err != nil {
+		logs.Log.Error("Kayıt oluşturulurken repository hatası", zap.String("entity", s.label), zap.Error(err))
+		if domainerrors.KindOf(err) != domainerrors.KindInternal {
+			return err
+		}
+		return domainerrors.Internal(fmt.Sprintf("%s veritabanına kaydedilemedi", s.label), err)
+	}
+	return nil
+}
+
+func (s *CRUDService[T]) Update(ctx context.Context, id uint, version uint, data map[string]interface{}) error {
+	currentUserID, ok := ctx.Value(contextUserIDKey).(uint)
+	if !ok || currentUserID == 0 {
+		logs.Log.Error("CRUDService.Update: Context'te geçerli user_id bulunamadı veya 0.", zap.String("entity", s.label))
+		return domainerrors.Forbidden("işlemi yapan kullanıcı kimliği context içinde bulunamadı")
+	}
+
+	if err := s.repo.Update(ctx, id, version, data, currentUserID); err != nil {
+		logs.Log.Error("Kayıt güncellenirken repository hatası", zap.String("entity", s.label), zap.Uint("id", id), zap.Error(err))
+		switch domainerrors.KindOf(err) {
+		case domainerrors.KindNotFound:
+			return domainerrors.WithMessage(err, fmt.Sprintf("%s bulunamadı", s.label))
+		case domainerrors.KindInternal:
+			return domainerrors.Internal(fmt.Sprintf("%s veritabanında güncellenemedi", s.label), err)
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *CRUDService[T]) Delete(ctx context.Context, id uint) error {
+	if err := s.repo.Delete(ctx, id); err != nil {
+		switch domainerrors.KindOf(err) {
+		case domainerrors.KindNotFound:
+			logs.Log.Warn("Kayıt silinemedi: Kayıt bulunamadı", zap.String("entity", s.label), zap.Uint("id", id))
+			return domainerrors.WithMessage(err, fmt.Sprintf("%s bulunamadı", s.label))
+		case domainerrors.KindInternal:
+			logs.Log.Error("Kayıt silinirken repository hatası", zap.String("entity", s.label), zap.Uint("id", id), zap.Error(err))
+			return domainerrors.Internal(fmt.Sprintf("%s silinirken bir veritabanı hatası oluştu", s.label), err)
+		}
+		logs.Log.Warn("Kayıt silinemedi", zap.String("entity", s.label), zap.Uint("id", id), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func NormalizeListParams(params queryparams.ListParams) queryparams.ListParams {
+	if params.Page <= 0 {
+		params.Page = queryparams.DefaultPage
+	}
+	defaultPerPage := settings.Int(settings.DefaultPerPage)
+	if defaultPerPage <= 0 || defaultPerPage > queryparams.MaxPerPage {
+		defaultPerPage = queryparams.DefaultPerPage
+	}
+	if params.PerPage <= 0 {
+		params.PerPage = defaultPerPage
+	} else if params.PerPage > queryparams.MaxPerPage {
+		logs.Log.Warn("Sayfa başına istenen kayıt sayısı limiti aştı, varsayılana çekildi.",
+			zap.Int("requested", params.PerPage),
+			zap.Int("max", queryparams.MaxPerPage),
+			zap.Int("default", defaultPerPage),
+		)
+		params.PerPage = defaultPerPage
+	}
+	if params.SortBy == "" && params.Name == "" {
+		params.SortBy = queryparams.DefaultSortBy
+	}
+	if params.OrderBy == "" {
+		params.OrderBy = queryparams.DefaultOrderBy
+	}
+	return params
+}
+
+var _ ICRUDService[struct{}] = (*CRUDService[struct{}])(nil)
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"context"
+
+	"zatrano/models"
+	"zatrano/pkg/domainerrors"
+	"zatrano/pkg/jobs"
+	"zatrano/pkg/logs"
+	"zatrano/pkg/tenant"
+
+	"go.uber.org/zap"
+)
+
+type NotificationJobPayload struct {
+	UserID         uint                `json:"user_id,omitempty"`
+	UserType       models.UserType     `json:"user_type,omitempty"`
+	OrganizationID uint                `json:"organization_id,omitempty"`
+	Message        NotificationMessage `json:"message"`
+}
+
+type PurgeJobsPayload struct {
+	OlderThanDays int `json:"older_than_days"`
+}
+
+var NotificationJob = jobs.Register(jobs.Handler[NotificationJobPayload]{
+	Name:        "notifications.send",
+	MaxAttempts: 3,
+	Handle: func(ctx context.Context, payload NotificationJobPayload) error {
+		if payload.OrganizationID != 0 {
+			ctx = tenant.WithOrganization(ctx, payload.OrganizationID)
+		}
+		notifications := NewNotificationService()
+		if payload.UserID != 0 {
+			return notifications.SendToUser(ctx, payload.UserID, payload.Message)
+		}
+		_, err := notifications.SendToUserType(ctx, payload.UserType, payload.Message)
+		return err
+	},
+})
+
+func enqueueNotification(ctx context.Context, queue IJobService, payload NotificationJobPayload) error {
+	if payload.OrganizationID == 0 {
+		payload.OrganizationID, _ = tenant.OrganizationID(ctx)
+	}
+	envelope, err := NotificationJob.New(payload)
+	if err != nil {
+		return err
+	}
+	_, err = queue.Enqueue(ctx, envelope)
+	return err
+}
+
+var PurgeJobsJob = jobs.Register(jobs.Handler[PurgeJobsPayload]{
+	Name:        "jobs.purge",
+	MaxAttempts: 1,
+	Handle: func(ctx context.Context, payload PurgeJobsPayload) error {
+		if payload.OlderThanDays <= 0 {
+			return domainerrors.Validation("older_than_days pozitif olmalıdır")
+		}
+		count, err := NewJobService().Purge(ctx, payload.OlderThanDays)
+		if err != nil {
+			return err
+		}
+		logs.Log.Info("Eski işler temizlendi", zap.Int("older_than_days", payload.OlderThanDays), zap.Int64("count", count))
+		return nil
+	},
+})
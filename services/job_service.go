@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"zatrano/models"
+	"zatrano/pkg/domainerrors"
+	"zatrano/pkg/jobs"
+	"zatrano/pkg/logs"
+	"zatrano/pkg/queryparams"
+	"zatrano/repositories"
+
+	"go.uber.org/zap"
+)
+
+var JobStatuses = []string{models.JobPending, models.JobRunning, models.JobFailed, models.JobCompleted}
+
+type JobPage struct {
+	Jobs   []models.Job
+	Meta   queryparams.PaginationMeta
+	Counts map[string]int64
+}
+
+func (p JobPage) Total() int64 {
+	var total int64
+	for _, count := range p.Counts {
+		total += count
+	}
+	return total
+}
+
+type IJobService interface {
+	Enqueue(ctx context.Context, envelope jobs.Envelope) (*models.Job, error)
+	List(ctx context.Context, status string, page, perPage int) (*JobPage, error)
+	Retry(ctx context.Context, id uint) error
+	Purge(ctx context.Context, olderThanDays int) (int64, error)
+}
+
+type JobService struct {
+	repo repositories.IJobRepository
+}
+
+func NewJobService() IJobService {
+	return &JobService{repo: repositories.NewJobRepository()}
+}
+
+func (s *JobService) Enqueue(ctx context.Context, envelope jobs.Envelope) (*models.Job, error) {
+	if envelope.Name == "" {
+		return nil, domainerrors.Validation("iş türü belirtilmedi")
+	}
+	runAt := envelope.RunAt
+	if runAt.IsZero() {
+		runAt = time.Now()
+	}
+	maxAttempts := envelope.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = jobs.DefaultMaxAttempts
+	}
+
+	job := &models.Job{
+		Type:        envelope.Name,
+		Payload:     envelope.Payload,
+		Status:      models.JobPending,
+		MaxAttempts: maxAttempts,
+		RunAt:       runAt.UTC(),
+	}
+	if userID, ok := ctx.Value(contextUserIDKey).(uint); ok && userID != 0 {
+		job.CreatedBy = &userID
+	}
+
+	if err := s.repo.Create(ctx, job); err != nil {
+		logs.Log.Error("İş kuyruğa eklenemedi", zap.String("type", envelope.Name), zap.Error(err))
+		return nil, domainerrors.Internal("iş kuyruğa eklenemedi", err)
+	}
+	logs.Log.Debug("İş kuyruğa eklendi", zap.Uint("job_id", job.ID), zap.String("type", job.Type), zap.Time("run_at", job.RunAt))
+	return job, nil
+}
+
+func (s *JobService) List(ctx context.Context, status string, page, perPage int) (*JobPage, error) {
+	if status != "" && !isJobStatus(status) {
+		return nil, domainerrors.Validation("geçersiz iş durumu").WithField("status")
+	}
+	params := NormalizeListParams(queryparams.ListParams{Page: page, PerPage: perPage})
+
+	rows, total, err := s.repo.List(ctx, status, params.CalculateOffset(), params.PerPage)
+	if err != nil {
+		logs.Log.Error("İşler listelenemedi", zap.String("status", status), zap.Error(err))
+		return nil, domainerrors.Internal("işler getirilirken bir hata oluştu", err)
+	}
+	counts, err := s.repo.CountByStatus(ctx)
+	if err != nil {
+		logs.Log.Warn("İş durum sayıları alınamadı", zap.Error(err))
+		counts = map[string]int64{}
+	}
+
+	return &JobPage{
+		Jobs: rows,
+		Meta: queryparams.PaginationMeta{
+			CurrentPage: params.Page,
+			PerPage:     params.PerPage,
+			TotalItems:  total,
+			TotalPages:  queryparams.CalculateTotalPages(total, params.PerPage),
+		},
+		Counts: counts,
+	}, nil
+}
+
+func (s *JobService) Retry(ctx context.Context, id uint) error {
+	if err := s.repo.Retry(ctx, id); err != nil {
+		if domainerrors.IsNotFound(err) {
+			return err
+		}
+		logs.Log.Error("İş yeniden kuyruğa alınamadı", zap.Uint("job_id", id), zap.Error(err))
+		return domainerrors.Internal("iş yeniden denenemedi", err)
+	}
+	currentUserID, _ := ctx.Value(contextUserIDKey).(uint)
+	logs.Log.Info("Başarısız iş yeniden kuyruğa alındı", zap.Uint("job_id", id), zap.Uint("user_id", currentUserID))
+	return nil
+}
+
+func (s *JobService) Purge(ctx context.Context, olderThanDays int) (int64, error) {
+	count, err := s.repo.DeleteCompletedBefore(ctx, time.Now().AddDate(0, 0, -olderThanDays))
+	if err != nil {
+		logs.Log.Error("Tamamlanan işler silinemedi", zap.Int("older_than_days", olderThanDays), zap.Error(err))
+		return 0, domainerrors.Internal("tamamlanan işler silinemedi", err)
+	}
+	return count, nil
+}
+
+func isJobStatus(status string) bool {
+	for _, candidate := range JobStatuses {
+		if candidate == status {
+			return true
+		}
+	}
+	return false
+}
+
+var _ IJobService = (*JobService)(nil)
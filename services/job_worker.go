@@ -0,0 +1,293 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"zatrano/models"
+	"zatrano/pkg/dbhealth"
+	"zatrano/pkg/domainerrors"
+	"zatrano/pkg/env"
+	"zatrano/pkg/jobs"
+	"zatrano/pkg/logs"
+	"zatrano/repositories"
+
+	"go.uber.org/zap"
+)
+
+const (
+	jobForcedStopGrace  = 5 * time.Second
+	jobMinStaleInterval = 30 * time.Second
+)
+
+type jobSchedule struct {
+	name     string
+	interval time.Duration
+	envelope func() (jobs.Envelope, error)
+}
+
+type JobWorkerPool struct {
+	repo         repositories.IJobRepository
+	queue        IJobService
+	schedules    []jobSchedule
+	workers      int
+	pollInterval time.Duration
+	lockTimeout  time.Duration
+	instance     string
+
+	stop    chan struct{}
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	started bool
+}
+
+func NewJobWorkerPool() *JobWorkerPool {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "zatrano"
+	}
+	return &JobWorkerPool{
+		repo:         repositories.NewJobRepository(),
+		queue:        NewJobService(),
+		schedules:    defaultJobSchedules(),
+		workers:      env.GetEnvAsInt("JOB_WORKERS", 2),
+		pollInterval: time.Duration(env.GetEnvAsInt("JOB_POLL_INTERVAL_MS", 1000)) * time.Millisecond,
+		lockTimeout:  time.Duration(env.GetEnvAsInt("JOB_LOCK_TIMEOUT_SECONDS", 900)) * time.Second,
+		instance:     fmt.Sprintf("%s-%d", hostname, os.Getpid()),
+	}
+}
+
+func (p *JobWorkerPool) Start() {
+	if p.started {
+		return
+	}
+	if p.workers <= 0 {
+		logs.Log.Info("İş çalışanları devre dışı (JOB_WORKERS=0)")
+		return
+	}
+	if p.pollInterval <= 0 {
+		p.pollInterval = time.Second
+	}
+	if p.lockTimeout < jobs.DefaultTimeout {
+		logs.Log.Warn("JOB_LOCK_TIMEOUT_SECONDS varsayılan iş zaman aşımından kısa, uzun süren işler tekrar kuyruğa alınabilir",
+			zap.Duration("lock_timeout", p.lockTimeout), zap.Duration("job_timeout", jobs.DefaultTimeout))
+	}
+
+	p.stop = make(chan struct{})
+	p.ctx, p.cancel = context.WithCancel(context.Background())
+	p.started = true
+
+	p.wg.Add(1)
+	go p.requeueStaleLoop()
+	for _, schedule := range p.schedules {
+		p.wg.Add(1)
+		go p.scheduleLoop(schedule)
+	}
+	for i := 1; i <= p.workers; i++ {
+		p.wg.Add(1)
+		go p.work(fmt.Sprintf("%s-%d", p.instance, i))
+	}
+
+	logs.Log.Info("İş çalışanları başlatıldı",
+		zap.Int("workers", p.workers),
+		zap.Duration("poll_interval", p.pollInterval),
+		zap.Strings("handlers", jobs.Names()),
+	)
+}
+
+func (p *JobWorkerPool) Stop(ctx context.Context) error {
+	if !p.started {
+		return nil
+	}
+	p.started = false
+	close(p.stop)
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		p.cancel()
+		logs.Log.Info("İş çalışanları durduruldu")
+		return nil
+	case <-ctx.Done():
+	}
+
+	logs.Log.Warn("İş çalışanları süresinde durmadı, çalışan işler iptal ediliyor")
+	p.cancel()
+	select {
+	case <-done:
+	case <-time.After(jobForcedStopGrace):
+		logs.Log.Error("Bazı işler iptale yanıt vermedi, kilitleri süre aşımında serbest bırakılacak")
+	}
+	return ctx.Err()
+}
+
+func (p *JobWorkerPool) work(workerID string) {
+	defer p.wg.Done()
+
+	for {
+		select {
+		case <-p.stop:
+			return
+		default:
+		}
+
+		if !dbhealth.Healthy() {
+			p.wait()
+			continue
+		}
+
+		job, err := p.repo.Claim(p.ctx, workerID)
+		if err != nil {
+			if !errors.Is(err, context.Canceled) {
+				logs.Log.Warn("Kuyruktan iş alınamadı", zap.String("worker", workerID), zap.Error(err))
+			}
+			p.wait()
+			continue
+		}
+		if job == nil {
+			p.wait()
+			continue
+		}
+
+		p.process(workerID, job)
+	}
+}
+
+func (p *JobWorkerPool) process(workerID string, job *models.Job) {
+	ctx := p.ctx
+	if job.CreatedBy != nil {
+		ctx = context.WithValue(ctx, contextUserIDKey, *job.CreatedBy)
+	}
+
+	fields := []zap.Field{
+		zap.Uint("job_id", job.ID),
+		zap.String("type", job.Type),
+		zap.Int("attempt", job.Attempts),
+		zap.Int("max_attempts", job.MaxAttempts),
+		zap.String("worker", workerID),
+	}
+	started := time.Now()
+	runErr := jobs.Run(ctx, job.Type, job.Payload)
+	fields = append(fields, zap.Duration("duration", time.Since(started)))
+
+	bookkeeping := context.Background()
+	var err error
+	switch {
+	case runErr == nil:
+		err = p.repo.Complete(bookkeeping, job.ID)
+		logs.Log.Info("İş tamamlandı", fields...)
+	case p.ctx.Err() != nil:
+		err = p.repo.Reschedule(bookkeeping, job.ID, time.Now(), runErr.Error())
+		logs.Log.Warn("İş kapatma sırasında yarıda kesildi, yeniden kuyruğa alındı", append(fields, zap.Error(runErr))...)
+	case isPermanentJobError(runErr) || job.Attempts >= job.MaxAttempts:
+		err = p.repo.Fail(bookkeeping, job.ID, runErr.Error())
+		logs.Log.Error("İş başarısız oldu ve yeniden denenmeyecek", append(fields, zap.Error(runErr))...)
+	default:
+		retryAt := time.Now().Add(jobs.Backoff(job.Attempts))
+		err = p.repo.Reschedule(bookkeeping, job.ID, retryAt, runErr.Error())
+		logs.Log.Warn("İş başarısız oldu, yeniden denenecek", append(fields, zap.Time("retry_at", retryAt), zap.Error(runErr))...)
+	}
+	if err != nil {
+		logs.Log.Error("İş durumu kaydedilemedi", append(fields, zap.Error(err))...)
+	}
+}
+
+func (p *JobWorkerPool) requeueStaleLoop() {
+	defer p.wg.Done()
+
+	interval := max(p.lockTimeout/2, jobMinStaleInterval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if dbhealth.Healthy() {
+			count, err := p.repo.RequeueStale(p.ctx, time.Now().Add(-p.lockTimeout))
+			if err != nil && !errors.Is(err, context.Canceled) {
+				logs.Log.Warn("Kilidi süresi dolan işler kuyruğa geri alınamadı", zap.Error(err))
+			} else if count > 0 {
+				logs.Log.Warn("Kilidi süresi dolan işler kuyruğa geri alındı", zap.Int64("count", count))
+			}
+		}
+
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *JobWorkerPool) scheduleLoop(schedule jobSchedule) {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(schedule.interval)
+	defer ticker.Stop()
+
+	for {
+		if dbhealth.Healthy() {
+			p.enqueueScheduled(schedule)
+		}
+
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *JobWorkerPool) enqueueScheduled(schedule jobSchedule) {
+	pending, err := p.repo.HasUnfinished(p.ctx, schedule.name)
+	if err != nil {
+		if !errors.Is(err, context.Canceled) {
+			logs.Log.Warn("Zamanlanmış iş kontrol edilemedi", zap.String("type", schedule.name), zap.Error(err))
+		}
+		return
+	}
+	if pending {
+		return
+	}
+
+	envelope, err := schedule.envelope()
+	if err == nil {
+		_, err = p.queue.Enqueue(p.ctx, envelope)
+	}
+	if err != nil {
+		logs.Log.Warn("Zamanlanmış iş kuyruğa eklenemedi", zap.String("type", schedule.name), zap.Error(err))
+	}
+}
+
+func defaultJobSchedules() []jobSchedule {
+	var schedules []jobSchedule
+	if days := env.GetEnvAsInt("JOB_RETENTION_DAYS", 7); days > 0 {
+		schedules = append(schedules, jobSchedule{
+			name:     PurgeJobsJob.Name(),
+			interval: time.Duration(max(env.GetEnvAsInt("JOB_PURGE_INTERVAL_HOURS", 24), 1)) * time.Hour,
+			envelope: func() (jobs.Envelope, error) {
+				return PurgeJobsJob.New(PurgeJobsPayload{OlderThanDays: days})
+			},
+		})
+	}
+	return schedules
+}
+
+func (p *JobWorkerPool) wait() {
+	select {
+	case <-p.stop:
+	case <-time.After(p.pollInterval):
+	}
+}
+
+func isPermanentJobError(err error) bool {
+	return jobs.Permanent(err) || domainerrors.IsValidation(err) || domainerrors.IsNotFound(err)
+}
@@ -0,0 +1,44 @@
+package services_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"zatrano/configs"
+	"zatrano/database/testdb"
+	"zatrano/models"
+	"zatrano/services"
+)
+
+func TestPasswordChangeByAdminQueuesNotification(t *testing.T) {
+	ctx := testdb.Organization(t)
+	admin := testdb.User(t, ctx, models.User{Account: "admin@test", Type: models.Dashboard})
+	agent := testdb.User(t, ctx, models.User{Account: "agent@test"})
+
+	update := *agent
+	update.Password = "newsecret1"
+	if err := services.NewUserService().UpdateUser(testdb.As(ctx, admin.ID), agent.ID, &update); err != nil {
+		t.Fatalf("şifre güncellenemedi: %v", err)
+	}
+
+	var jobs []models.Job
+	if err := configs.GetDB().Where("type = ?", services.NotificationJob.Name()).Find(&jobs).Error; err != nil {
+		t.Fatal(err)
+	}
+	for _, job := range jobs {
+		var payload services.NotificationJobPayload
+		if err := json.Unmarshal([]byte(job.Payload), &payload); err != nil {
+			t.Fatal(err)
+		}
+		if payload.UserID == agent.ID {
+			if payload.OrganizationID != agent.OrganizationID {
+				t.Fatalf("bildirim işi organizasyonu taşımıyor: %+v", payload)
+			}
+			if job.CreatedBy == nil || *job.CreatedBy != admin.ID {
+				t.Fatalf("bildirim işini oluşturan kullanıcı kaydedilmedi: %v", job.CreatedBy)
+			}
+			return
+		}
+	}
+	t.Fatalf("%d numaralı kullanıcı için bildirim işi kuyruğa eklenmedi", agent.ID)
+}
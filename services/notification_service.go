@@ -0,0 +1,198 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"zatrano/models"
+	"zatrano/pkg/cache"
+	"zatrano/pkg/domainerrors"
+	"zatrano/pkg/logs"
+	"zatrano/pkg/queryparams"
+	"zatrano/repositories"
+
+	"go.uber.org/zap"
+)
+
+const unreadCountCacheTTL = 30 * time.Second
+
+var unreadCountCache = cache.NewTTL(unreadCountCacheTTL)
+
+type NotificationMessage struct {
+	Title string
+	Body  string
+	Link  string
+	Level string
+}
+
+type NotificationPage struct {
+	Notifications []models.Notification
+	Meta          queryparams.PaginationMeta
+}
+
+type INotificationService interface {
+	SendToUser(ctx context.Context, userID uint, message NotificationMessage) error
+	SendToUserType(ctx context.Context, userType models.UserType, message NotificationMessage) (int, error)
+	UnreadCount(userID uint) int64
+	List(userID uint, unreadOnly bool, page, perPage int) (*NotificationPage, error)
+	MarkRead(userID, id uint) (*models.Notification, error)
+	MarkAllRead(userID uint) (int64, error)
+}
+
+type NotificationService struct {
+	repo repositories.INotificationRepository
+}
+
+func NewNotificationService() INotificationService {
+	return &NotificationService{repo: repositories.NewNotificationRepository()}
+}
+
+func (s *NotificationService) SendToUser(ctx context.Context, userID uint, message NotificationMessage) error {
+	if userID == 0 {
+		return domainerrors.Validation("bildirim alıcısı belirtilmedi")
+	}
+	message, err := normalizeNotification(message)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.Create(ctx, []models.Notification{message.toModel(userID)}); err != nil {
+		logs.Log.Error("Bildirim gönderilemedi", zap.Uint("user_id", userID), zap.Error(err))
+		return domainerrors.Internal("bildirim gönderilemedi", err)
+	}
+	unreadCountCache.Delete(unreadCountKey(userID))
+	return nil
+}
+
+func (s *NotificationService) SendToUserType(ctx context.Context, userType models.UserType, message NotificationMessage) (int, error) {
+	if userType != models.Dashboard && userType != models.Panel {
+		return 0, domainerrors.Validation("geçersiz kullanıcı tipi")
+	}
+	message, err := normalizeNotification(message)
+	if err != nil {
+		return 0, err
+	}
+
+	userIDs, err := s.repo.FindActiveUserIDsByType(ctx, userType)
+	if err != nil {
+		logs.Log.Error("Bildirim alıcıları bulunamadı", zap.String("type", string(userType)), zap.Error(err))
+		return 0, domainerrors.Internal("bildirim alıcıları belirlenemedi", err)
+	}
+
+	notifications := make([]models.Notification, len(userIDs))
+	for i, userID := range userIDs {
+		notifications[i] = message.toModel(userID)
+	}
+	if err := s.repo.Create(ctx, notifications); err != nil {
+		logs.Log.Error("Toplu bildirim gönderilemedi", zap.String("type", string(userType)), zap.Int("recipients", len(userIDs)), zap.Error(err))
+		return 0, domainerrors.Internal("bildirim gönderilemedi", err)
+	}
+	for _, userID := range userIDs {
+		unreadCountCache.Delete(unreadCountKey(userID))
+	}
+
+	logs.Log.Info("Kullanıcı tipine bildirim gönderildi", zap.String("type", string(userType)), zap.Int("recipients", len(userIDs)))
+	return len(userIDs), nil
+}
+
+func (s *NotificationService) UnreadCount(userID uint) int64 {
+	value, err := unreadCountCache.Remember(unreadCountKey(userID), func() (interface{}, error) {
+		return s.repo.CountUnread(userID)
+	})
+	if err != nil {
+		logs.Log.Warn("Okunmamış bildirim sayısı alınamadı", zap.Uint("user_id", userID), zap.Error(err))
+		return 0
+	}
+	count, _ := value.(int64)
+	return count
+}
+
+func (s *NotificationService) List(userID uint, unreadOnly bool, page, perPage int) (*NotificationPage, error) {
+	params := NormalizeListParams(queryparams.ListParams{Page: page, PerPage: perPage})
+	rows, total, err := s.repo.ListForUser(userID, unreadOnly, params.CalculateOffset(), params.PerPage)
+	if err != nil {
+		logs.Log.Error("Bildirimler listelenemedi", zap.Uint("user_id", userID), zap.Error(err))
+		return nil, domainerrors.Internal("bildirimler getirilirken bir hata oluştu", err)
+	}
+	return &NotificationPage{
+		Notifications: rows,
+		Meta: queryparams.PaginationMeta{
+			CurrentPage: params.Page,
+			PerPage:     params.PerPage,
+			TotalItems:  total,
+			TotalPages:  queryparams.CalculateTotalPages(total, params.PerPage),
+		},
+	}, nil
+}
+
+func (s *NotificationService) MarkRead(userID, id uint) (*models.Notification, error) {
+	notification, err := s.repo.GetForUser(userID, id)
+	if err != nil {
+		if domainerrors.IsNotFound(err) {
+			return nil, domainerrors.WithMessage(err, "bildirim bulunamadı")
+		}
+		return nil, domainerrors.Internal("bildirim alınamadı", err)
+	}
+	if notification.IsRead() {
+		return notification, nil
+	}
+	if err := s.repo.MarkRead(userID, id); err != nil {
+		logs.Log.Error("Bildirim okundu olarak işaretlenemedi", zap.Uint("user_id", userID), zap.Uint("id", id), zap.Error(err))
+		return nil, domainerrors.Internal("bildirim güncellenemedi", err)
+	}
+	now := time.Now()
+	notification.ReadAt = &now
+	unreadCountCache.Delete(unreadCountKey(userID))
+	return notification, nil
+}
+
+func (s *NotificationService) MarkAllRead(userID uint) (int64, error) {
+	count, err := s.repo.MarkAllRead(userID)
+	if err != nil {
+		logs.Log.Error("Bildirimler okundu olarak işaretlenemedi", zap.Uint("user_id", userID), zap.Error(err))
+		return 0, domainerrors.Internal("bildirimler güncellenemedi", err)
+	}
+	unreadCountCache.Delete(unreadCountKey(userID))
+	return count, nil
+}
+
+func normalizeNotification(message NotificationMessage) (NotificationMessage, error) {
+	message.Title = strings.TrimSpace(message.Title)
+	message.Link = strings.TrimSpace(message.Link)
+	if message.Title == "" {
+		return message, domainerrors.Validation("bildirim başlığı boş olamaz").WithField("title")
+	}
+	if utf8.RuneCountInString(message.Title) > 150 {
+		return message, domainerrors.Validation("bildirim başlığı en fazla 150 karakter olabilir").WithField("title")
+	}
+	if message.Link != "" && (!strings.HasPrefix(message.Link, "/") || strings.HasPrefix(message.Link, "//") || strings.HasPrefix(message.Link, "/\\") || len(message.Link) > 255) {
+		return message, domainerrors.Validation("bildirim bağlantısı uygulama içi bir yol olmalıdır").WithField("link")
+	}
+	switch message.Level {
+	case "":
+		message.Level = models.NotificationInfo
+	case models.NotificationInfo, models.NotificationSuccess, models.NotificationWarning, models.NotificationDanger:
+	default:
+		return message, domainerrors.Validation("geçersiz bildirim seviyesi").WithField("level")
+	}
+	return message, nil
+}
+
+func (m NotificationMessage) toModel(userID uint) models.Notification {
+	return models.Notification{
+		UserID: userID,
+		Title:  m.Title,
+		Body:   m.Body,
+		Link:   m.Link,
+		Level:  m.Level,
+	}
+}
+
+func unreadCountKey(userID uint) string {
+	return fmt.Sprintf("unread:%d", userID)
+}
+
+var _ INotificationService = (*NotificationService)(nil)
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"zatrano/models"
+	"zatrano/pkg/cache"
+	"zatrano/pkg/domainerrors"
+	"zatrano/pkg/logs"
+	"zatrano/repositories"
+
+	"go.uber.org/zap"
+)
+
+const organizationCacheTTL = time.Minute
+
+var organizationCache = cache.NewTTL(organizationCacheTTL)
+
+var (
+	ErrOrganizationInactive = domainerrors.Forbidden("organizasyonunuz aktif değil")
+	ErrNotSuperAdmin        = domainerrors.Forbidden("bu işlem yalnızca süper yöneticiler tarafından yapılabilir")
+)
+
+type IOrganizationService interface {
+	GetAll() ([]models.Organization, error)
+	GetSummaries() ([]repositories.OrganizationSummary, error)
+	GetByID(id uint) (*models.Organization, error)
+	Create(ctx context.Context, name string) (*models.Organization, error)
+	SetStatus(ctx context.Context, id uint, status bool) error
+	ResolveCurrent(user *models.User, selectedID uint) (*models.Organization, error)
+}
+
+type OrganizationService struct {
+	repo repositories.IOrganizationRepository
+}
+
+func NewOrganizationService() IOrganizationService {
+	return &OrganizationService{repo: repositories.NewOrganizationRepository()}
+}
+
+func (s *OrganizationService) GetAll() ([]models.Organization, error) {
+	value, err := organizationCache.Remember("all", func() (interface{}, error) {
+		return s.repo.GetAll()
+	})
+	if err != nil {
+		logs.Log.Error("Organizasyonlar alınamadı", zap.Error(err))
+		return nil, domainerrors.Internal("organizasyonlar alınırken bir hata oluştu", err)
+	}
+	return value.([]models.Organization), nil
+}
+
+func (s *OrganizationService) GetSummaries() ([]repositories.OrganizationSummary, error) {
+	summaries, err := s.repo.GetSummaries()
+	if err != nil {
+		logs.Log.Error("Organizasyon özetleri alınamadı", zap.Error(err))
+		return nil, domainerrors.Internal("organizasyonlar alınırken bir hata oluştu", err)
+	}
+	return summaries, nil
+}
+
+func (s *OrganizationService) GetByID(id uint) (*models.Organization, error) {
+	value, err := organizationCache.Remember(fmt.Sprintf("id:%d", id), func() (interface{}, error) {
+		return s.repo.GetByID(id)
+	})
+	if err != nil {
+		if domainerrors.IsNotFound(err) {
+			return nil, domainerrors.WithMessage(err, "organizasyon bulunamadı")
+		}
+		logs.Log.Error("Organizasyon alınamadı", zap.Uint("organization_id", id), zap.Error(err))
+		return nil, domainerrors.Internal("organizasyon bilgileri alınırken bir hata oluştu", err)
+	}
+	return value.(*models.Organization), nil
+}
+
+func (s *OrganizationService) Create(ctx context.Context, name string) (*models.Organization, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, domainerrors.Validation("organizasyon adı boş olamaz").WithField("name")
+	}
+	if utf8.RuneCountInString(name) > 150 {
+		return nil, domainerrors.Validation("organizasyon adı en fazla 150 karakter olabilir").WithField("name")
+	}
+
+	organization := &models.Organization{Name: name, Status: true}
+	if err := s.repo.Create(ctx, organization); err != nil {
+		if domainerrors.KindOf(err) != domainerrors.KindInternal {
+			return nil, err
+		}
+		logs.Log.Error("Organizasyon oluşturulamadı", zap.String("name", name), zap.Error(err))
+		return nil, domainerrors.Internal("organizasyon oluşturulamadı", err)
+	}
+
+	organizationCache.Clear()
+	logs.SLog.Infof("Organizasyon oluşturuldu: %s (ID: %d)", organization.Name, organization.ID)
+	return organization, nil
+}
+
+func (s *OrganizationService) SetStatus(ctx context.Context, id uint, status bool) error {
+	if err := s.repo.UpdateStatus(ctx, id, status); err != nil {
+		if domainerrors.IsNotFound(err) {
+			return domainerrors.WithMessage(err, "organizasyon bulunamadı")
+		}
+		logs.Log.Error("Organizasyon durumu güncellenemedi", zap.Uint("organization_id", id), zap.Error(err))
+		return domainerrors.Internal("organizasyon durumu güncellenemedi", err)
+	}
+	organizationCache.Clear()
+	return nil
+}
+
+func (s *OrganizationService) ResolveCurrent(user *models.User, selectedID uint) (*models.Organization, error) {
+	if user.SuperAdmin && selectedID != 0 && selectedID != user.OrganizationID {
+		organization, err := s.GetByID(selectedID)
+		if err == nil {
+			return organization, nil
+		}
+		logs.Log.Warn("Seçili organizasyon bulunamadı, kullanıcının kendi organizasyonu kullanılıyor",
+			zap.Uint("user_id", user.ID),
+			zap.Uint("organization_id", selectedID),
+			zap.Error(err),
+		)
+	}
+
+	organization, err := s.GetByID(user.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	if !organization.Status && !user.SuperAdmin {
+		return nil, ErrOrganizationInactive
+	}
+	return organization, nil
+}
+
+var _ IOrganizationService = (*OrganizationService)(nil)
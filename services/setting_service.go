@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"zatrano/pkg/domainerrors"
+	"zatrano/pkg/logs"
+	"zatrano/pkg/settings"
+	"zatrano/pkg/validation"
+	"zatrano/repositories"
+
+	"go.uber.org/zap"
+)
+
+type SettingItem struct {
+	settings.Definition
+	Value     string
+	IsDefault bool
+	UpdatedAt *time.Time
+}
+
+type SettingGroup struct {
+	Category settings.Category
+	Items    []SettingItem
+}
+
+type ISettingService interface {
+	GetGroups() ([]SettingGroup, error)
+	Update(ctx context.Context, input map[string]string) error
+	LoadValues() (map[string]string, error)
+}
+
+type SettingService struct {
+	repo repositories.ISettingRepository
+}
+
+func NewSettingService() ISettingService {
+	return &SettingService{repo: repositories.NewSettingRepository()}
+}
+
+func InitSettings() {
+	settings.SetLoader(NewSettingService().LoadValues)
+	logs.SLog.Info("Uygulama ayarları veritabanı kaynağına bağlandı.")
+}
+
+func (s *SettingService) LoadValues() (map[string]string, error) {
+	rows, err := s.repo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]string, len(rows))
+	for _, row := range rows {
+		values[row.Key] = row.Value
+	}
+	return values, nil
+}
+
+func (s *SettingService) GetGroups() ([]SettingGroup, error) {
+	rows, err := s.repo.GetAll()
+	if err != nil {
+		logs.Log.Error("Ayarlar listelenemedi", zap.Error(err))
+		return nil, domainerrors.WithMessage(err, "Ayarlar getirilirken bir hata oluştu.")
+	}
+	stored := make(map[string]int, len(rows))
+	for i, row := range rows {
+		stored[row.Key] = i
+	}
+
+	groups := make([]SettingGroup, 0, len(settings.Categories))
+	for _, category := range settings.Categories {
+		group := SettingGroup{Category: category}
+		for _, d := range settings.Definitions() {
+			if d.Category != category.Key {
+				continue
+			}
+			item := SettingItem{Definition: d, Value: d.DefaultValue(), IsDefault: true}
+			if i, ok := stored[d.Key]; ok {
+				if normalized, err := d.Normalize(rows[i].Value); err == nil {
+					item.Value = normalized
+					item.IsDefault = false
+					item.UpdatedAt = &rows[i].UpdatedAt
+				}
+			}
+			group.Items = append(group.Items, item)
+		}
+		if len(group.Items) > 0 {
+			groups = append(groups, group)
+		}
+	}
+	return groups, nil
+}
+
+func (s *SettingService) Update(ctx context.Context, input map[string]string) error {
+	stored, err := s.LoadValues()
+	if err != nil {
+		logs.Log.Error("Ayarlar güncellenmeden önce okunamadı", zap.Error(err))
+		return domainerrors.WithMessage(err, "Ayarlar kaydedilemedi.")
+	}
+
+	errs := validation.Errors{}
+	values := make(map[string]string)
+	for _, d := range settings.Definitions() {
+		raw, submitted := input[d.Key]
+		if !submitted && d.Type != settings.TypeBool {
+			continue
+		}
+		normalized, err := d.Normalize(raw)
+		if err != nil {
+			errs[d.Key] = err.Error()
+			continue
+		}
+		current, exists := stored[d.Key]
+		if (exists && current == normalized) || (!exists && normalized == d.DefaultValue()) {
+			continue
+		}
+		values[d.Key] = normalized
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	if len(values) == 0 {
+		return nil
+	}
+
+	currentUserID, _ := ctx.Value(contextUserIDKey).(uint)
+	if err := s.repo.SaveAll(ctx, values, currentUserID); err != nil {
+		logs.Log.Error("Ayarlar kaydedilemedi", zap.Uint("updated_by", currentUserID), zap.Error(err))
+		return domainerrors.WithMessage(err, "Ayarlar kaydedilemedi.")
+	}
+
+	settings.Invalidate()
+	logs.Log.Info("Uygulama ayarları güncellendi", zap.Uint("updated_by", currentUserID), zap.Int("count", len(values)))
+	return nil
+}
+
+var _ ISettingService = (*SettingService)(nil)
@@ -0,0 +1,299 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"zatrano/models"
+	"zatrano/pkg/cache"
+	"zatrano/pkg/domainerrors"
+	"zatrano/pkg/env"
+	"zatrano/pkg/logs"
+	"zatrano/pkg/ownership"
+	"zatrano/pkg/tenant"
+	"zatrano/repositories"
+
+	"go.uber.org/zap"
+)
+
+const (
+	DefaultStatsRangeDays = 30
+	MaxStatsRangeDays     = 366
+	DefaultRecentLimit    = 10
+	MaxRecentLimit        = 50
+	statsCacheTTL         = time.Minute
+)
+
+var statsCache = cache.NewTTL(statsCacheTTL)
+
+type UserBreakdown struct {
+	Total    int64                              `json:"total"`
+	Active   int64                              `json:"active"`
+	Inactive int64                              `json:"inactive"`
+	ByType   map[models.UserType]int64          `json:"by_type"`
+	Rows     []repositories.UserTypeStatusCount `json:"rows"`
+}
+
+type SeriesData struct {
+	Name string  `json:"name"`
+	Data []int64 `json:"data"`
+}
+
+type TimeSeries struct {
+	Bucket string       `json:"bucket"`
+	From   string       `json:"from"`
+	To     string       `json:"to"`
+	Labels []string     `json:"labels"`
+	Series []SeriesData `json:"series"`
+}
+
+func (t *TimeSeries) Total(name string) int64 {
+	var total int64
+	for _, series := range t.Series {
+		if series.Name != name {
+			continue
+		}
+		for _, value := range series.Data {
+			total += value
+		}
+	}
+	return total
+}
+
+type RecentUser struct {
+	ID        uint            `json:"id"`
+	Name      string          `json:"name"`
+	Account   string          `json:"account"`
+	Type      models.UserType `json:"type"`
+	Status    bool            `json:"status"`
+	UpdatedAt time.Time       `json:"updated_at"`
+	UpdatedBy uint            `json:"updated_by"`
+}
+
+type IStatsService interface {
+	GetUserBreakdown(ctx context.Context) (*UserBreakdown, error)
+	GetUserRegistrations(ctx context.Context, days int, bucket string) (*TimeSeries, error)
+	GetLoginActivity(ctx context.Context, days int) (*TimeSeries, error)
+	GetRecentlyModifiedUsers(ctx context.Context, limit int) ([]RecentUser, error)
+}
+
+type StatsService struct {
+	repo repositories.IStatsRepository
+}
+
+func NewStatsService() IStatsService {
+	return &StatsService{repo: repositories.NewStatsRepository()}
+}
+
+func InvalidateStatsCache() {
+	statsCache.Clear()
+}
+
+func (s *StatsService) GetUserBreakdown(ctx context.Context) (*UserBreakdown, error) {
+	value, err := statsCache.Remember(statsCacheKey(ctx, "users:breakdown"), func() (interface{}, error) {
+		rows, err := s.repo.CountUsersByTypeAndStatus(ctx)
+		if err != nil {
+			return nil, err
+		}
+		breakdown := &UserBreakdown{
+			ByType: map[models.UserType]int64{models.Dashboard: 0, models.Panel: 0},
+			Rows:   rows,
+		}
+		for _, row := range rows {
+			breakdown.Total += row.Count
+			breakdown.ByType[row.Type] += row.Count
+			if row.Status {
+				breakdown.Active += row.Count
+			} else {
+				breakdown.Inactive += row.Count
+			}
+		}
+		return breakdown, nil
+	})
+	if err != nil {
+		logs.Log.Error("Kullanıcı dağılımı istatistiği alınamadı", zap.Error(err))
+		return nil, domainerrors.Internal("kullanıcı istatistikleri alınırken bir hata oluştu", err)
+	}
+	return value.(*UserBreakdown), nil
+}
+
+func (s *StatsService) GetUserRegistrations(ctx context.Context, days int, bucket string) (*TimeSeries, error) {
+	if bucket == "" {
+		bucket = repositories.BucketDay
+	}
+	if bucket != repositories.BucketDay && bucket != repositories.BucketWeek && bucket != repositories.BucketMonth {
+		return nil, domainerrors.Validation("geçersiz zaman aralığı; day, week veya month kullanın").WithField("bucket")
+	}
+	days = normalizeStatsDays(days)
+
+	key := statsCacheKey(ctx, fmt.Sprintf("users:registrations:%d:%s", days, bucket))
+	value, err := statsCache.Remember(key, func() (interface{}, error) {
+		from, to := statsRange(days, bucket)
+		rows, err := s.repo.CountUsersCreated(ctx, from, to, bucket)
+		if err != nil {
+			return nil, err
+		}
+		counts := make(map[string]int64, len(rows))
+		for _, row := range rows {
+			counts[normalizeBucketKey(row.Bucket)] = row.Count
+		}
+
+		series := newTimeSeries(from, to, bucket)
+		data := make([]int64, len(series.Labels))
+		for i, label := range series.Labels {
+			data[i] = counts[label]
+		}
+		series.Series = []SeriesData{{Name: "Yeni Kullanıcı", Data: data}}
+		return series, nil
+	})
+	if err != nil {
+		logs.Log.Error("Kullanıcı kayıt istatistiği alınamadı", zap.String("bucket", bucket), zap.Error(err))
+		return nil, domainerrors.Internal("kullanıcı kayıt istatistikleri alınırken bir hata oluştu", err)
+	}
+	return value.(*TimeSeries), nil
+}
+
+func (s *StatsService) GetLoginActivity(ctx context.Context, days int) (*TimeSeries, error) {
+	days = normalizeStatsDays(days)
+
+	key := statsCacheKey(ctx, fmt.Sprintf("logins:%d", days))
+	value, err := statsCache.Remember(key, func() (interface{}, error) {
+		from, to := statsRange(days, repositories.BucketDay)
+		rows, err := s.repo.CountLogins(ctx, from, to)
+		if err != nil {
+			return nil, err
+		}
+		byBucket := make(map[string]repositories.LoginBucketCount, len(rows))
+		for _, row := range rows {
+			byBucket[normalizeBucketKey(row.Bucket)] = row
+		}
+
+		series := newTimeSeries(from, to, repositories.BucketDay)
+		logins := make([]int64, len(series.Labels))
+		uniqueUsers := make([]int64, len(series.Labels))
+		for i, label := range series.Labels {
+			logins[i] = byBucket[label].Count
+			uniqueUsers[i] = byBucket[label].UniqueUsers
+		}
+		series.Series = []SeriesData{
+			{Name: "Giriş", Data: logins},
+			{Name: "Tekil Kullanıcı", Data: uniqueUsers},
+		}
+		return series, nil
+	})
+	if err != nil {
+		logs.Log.Error("Giriş istatistiği alınamadı", zap.Error(err))
+		return nil, domainerrors.Internal("giriş istatistikleri alınırken bir hata oluştu", err)
+	}
+	return value.(*TimeSeries), nil
+}
+
+func (s *StatsService) GetRecentlyModifiedUsers(ctx context.Context, limit int) ([]RecentUser, error) {
+	if limit <= 0 {
+		limit = DefaultRecentLimit
+	} else if limit > MaxRecentLimit {
+		limit = MaxRecentLimit
+	}
+
+	key := statsCacheKey(ctx, fmt.Sprintf("users:recent:%d", limit))
+	value, err := statsCache.Remember(key, func() (interface{}, error) {
+		users, err := s.repo.GetRecentlyModifiedUsers(ctx, limit)
+		if err != nil {
+			return nil, err
+		}
+		recent := make([]RecentUser, 0, len(users))
+		for _, user := range users {
+			recent = append(recent, RecentUser{
+				ID:        user.ID,
+				Name:      user.Name,
+				Account:   user.Account,
+				Type:      user.Type,
+				Status:    user.Status,
+				UpdatedAt: user.UpdatedAt,
+				UpdatedBy: user.UpdatedBy,
+			})
+		}
+		return recent, nil
+	})
+	if err != nil {
+		logs.Log.Error("Son değiştirilen kullanıcılar alınamadı", zap.Error(err))
+		return nil, domainerrors.Internal("son değiştirilen kullanıcılar alınırken bir hata oluştu", err)
+	}
+	return value.([]RecentUser), nil
+}
+
+func statsCacheKey(ctx context.Context, key string) string {
+	organizationID, _ := tenant.OrganizationID(ctx)
+	managerID, _ := ownership.ManagerID(ctx)
+	return fmt.Sprintf("org:%d:manager:%d:%s", organizationID, managerID, key)
+}
+
+func normalizeStatsDays(days int) int {
+	if days <= 0 {
+		return DefaultStatsRangeDays
+	}
+	if days > MaxStatsRangeDays {
+		return MaxStatsRangeDays
+	}
+	return days
+}
+
+func statsLocation() *time.Location {
+	loc, err := time.LoadLocation(env.GetEnvWithDefault("DB_TIMEZONE", "UTC"))
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+func truncateToBucket(t time.Time, bucket string) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	switch bucket {
+	case repositories.BucketWeek:
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case repositories.BucketMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	default:
+		return day
+	}
+}
+
+func nextBucket(t time.Time, bucket string) time.Time {
+	switch bucket {
+	case repositories.BucketWeek:
+		return t.AddDate(0, 0, 7)
+	case repositories.BucketMonth:
+		return t.AddDate(0, 1, 0)
+	default:
+		return t.AddDate(0, 0, 1)
+	}
+}
+
+func statsRange(days int, bucket string) (time.Time, time.Time) {
+	now := time.Now().In(statsLocation())
+	to := truncateToBucket(now, repositories.BucketDay).AddDate(0, 0, 1)
+	from := truncateToBucket(to.AddDate(0, 0, -days), bucket)
+	return from, to
+}
+
+func newTimeSeries(from, to time.Time, bucket string) *TimeSeries {
+	series := &TimeSeries{
+		Bucket: bucket,
+		From:   from.Format("2006-01-02"),
+		To:     to.AddDate(0, 0, -1).Format("2006-01-02"),
+	}
+	for cursor := from; cursor.Before(to); cursor = nextBucket(cursor, bucket) {
+		series.Labels = append(series.Labels, cursor.Format("2006-01-02"))
+	}
+	return series
+}
+
+func normalizeBucketKey(bucket string) string {
+	if len(bucket) > len("2006-01-02") {
+		return bucket[:len("2006-01-02")]
+	}
+	return bucket
+}
+
+var _ IStatsService = (*StatsService)(nil)
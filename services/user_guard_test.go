@@ -0,0 +1,98 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	"zatrano/database/testdb"
+	"zatrano/models"
+	"zatrano/services"
+)
+
+func TestCannotLockOutSelf(t *testing.T) {
+	ctx := testdb.Organization(t)
+	admin := testdb.User(t, ctx, models.User{Account: "admin@test", Type: models.Dashboard})
+	testdb.User(t, ctx, models.User{Account: "other@test", Type: models.Dashboard})
+	ctx = testdb.As(ctx, admin.ID)
+	service := services.NewUserService()
+
+	tests := []struct {
+		name   string
+		change func(*models.User)
+		want   error
+	}{
+		{name: "deactivate", change: func(u *models.User) { u.Status = false }, want: services.ErrCannotDeactivateSelf},
+		{name: "demote", change: func(u *models.User) { u.Type = models.Panel }, want: services.ErrCannotDemoteSelf},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			update := *admin
+			tt.change(&update)
+			if err := service.UpdateUser(ctx, admin.ID, &update); !errors.Is(err, tt.want) {
+				t.Fatalf("beklenen %v, dönen: %v", tt.want, err)
+			}
+		})
+	}
+	if err := service.DeleteUser(ctx, admin.ID); !errors.Is(err, services.ErrCannotDeleteSelf) {
+		t.Fatalf("kendi hesabını silme reddedilmeli, dönen: %v", err)
+	}
+}
+
+func TestProtectedUserCannotBeRemoved(t *testing.T) {
+	ctx := testdb.Organization(t)
+	admin := testdb.User(t, ctx, models.User{Account: "admin@test", Type: models.Dashboard})
+	protected := testdb.User(t, ctx, models.User{Account: "protected@test", Type: models.Dashboard, Protected: true})
+	ctx = testdb.As(ctx, admin.ID)
+	service := services.NewUserService()
+
+	deactivate := *protected
+	deactivate.Status = false
+	if err := service.UpdateUser(ctx, protected.ID, &deactivate); !errors.Is(err, services.ErrProtectedUser) {
+		t.Fatalf("korumalı kullanıcı pasif yapılamamalı, dönen: %v", err)
+	}
+
+	demote := *protected
+	demote.Type = models.Panel
+	if err := service.UpdateUser(ctx, protected.ID, &demote); !errors.Is(err, services.ErrProtectedUser) {
+		t.Fatalf("korumalı kullanıcının yetkisi kaldırılamamalı, dönen: %v", err)
+	}
+
+	if err := service.DeleteUser(ctx, protected.ID); !errors.Is(err, services.ErrProtectedUser) {
+		t.Fatalf("korumalı kullanıcı silinememeli, dönen: %v", err)
+	}
+
+	rename := *protected
+	rename.Name = "Yeni Ad"
+	if err := service.UpdateUser(ctx, protected.ID, &rename); err != nil {
+		t.Fatalf("korumalı kullanıcının diğer alanları güncellenebilmeli: %v", err)
+	}
+}
+
+func TestLastActiveDashboardCannotBeRemoved(t *testing.T) {
+	ctx := testdb.Organization(t)
+	first := testdb.User(t, ctx, models.User{Account: "first@test", Type: models.Dashboard})
+	second := testdb.User(t, ctx, models.User{Account: "second@test", Type: models.Dashboard})
+	service := services.NewUserService()
+
+	deactivate := *second
+	deactivate.Status = false
+	if err := service.UpdateUser(testdb.As(ctx, first.ID), second.ID, &deactivate); err != nil {
+		t.Fatalf("ikinci yönetici pasif yapılamadı: %v", err)
+	}
+
+	deactivate = *first
+	deactivate.Status = false
+	if err := service.UpdateUser(testdb.As(ctx, second.ID), first.ID, &deactivate); !errors.Is(err, services.ErrLastActiveDashboard) {
+		t.Fatalf("son aktif yönetici pasif yapılamamalı, dönen: %v", err)
+	}
+
+	demote := *first
+	demote.Type = models.Panel
+	if err := service.UpdateUser(testdb.As(ctx, second.ID), first.ID, &demote); !errors.Is(err, services.ErrLastActiveDashboard) {
+		t.Fatalf("son aktif yöneticinin yetkisi kaldırılamamalı, dönen: %v", err)
+	}
+
+	if err := service.DeleteUser(testdb.As(ctx, second.ID), first.ID); !errors.Is(err, services.ErrLastActiveDashboard) {
+		t.Fatalf("son aktif yönetici silinememeli, dönen: %v", err)
+	}
+}
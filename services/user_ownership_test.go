@@ -0,0 +1,88 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"zatrano/configs"
+	"zatrano/database/testdb"
+	"zatrano/models"
+	"zatrano/pkg/ownership"
+	"zatrano/services"
+)
+
+func TestReassignAgents(t *testing.T) {
+	ctx := testdb.Organization(t)
+	admin := testdb.User(t, ctx, models.User{Account: "admin@test", Type: models.Dashboard})
+	manager := testdb.User(t, ctx, models.User{Account: "manager@test", Type: models.Dashboard})
+	first := testdb.User(t, ctx, models.User{Account: "agent1@test"})
+	second := testdb.User(t, ctx, models.User{Account: "agent2@test", ManagerID: &admin.ID})
+	ids := []uint{first.ID, second.ID, manager.ID}
+	service := services.NewUserService()
+	ctx = testdb.As(ctx, admin.ID)
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		ids       []uint
+		managerID uint
+		affected  int64
+		err       error
+	}{
+		{name: "no users", ctx: ctx, managerID: manager.ID, err: services.ErrNoUsersSelected},
+		{name: "panel user as manager", ctx: ctx, ids: ids, managerID: first.ID, err: services.ErrInvalidManager},
+		{name: "restricted manager", ctx: ownership.WithManager(ctx, manager.ID), ids: ids, managerID: manager.ID, err: services.ErrOwnAgentsOnly},
+		{name: "assign skips dashboard users", ctx: ctx, ids: ids, managerID: manager.ID, affected: 2},
+		{name: "already assigned", ctx: ctx, ids: ids, managerID: manager.ID, affected: 0},
+		{name: "unassign", ctx: ctx, ids: ids, affected: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			affected, err := service.ReassignAgents(tt.ctx, tt.ids, tt.managerID)
+			if !errors.Is(err, tt.err) || affected != tt.affected {
+				t.Fatalf("beklenen %d, %v; dönen %d, %v", tt.affected, tt.err, affected, err)
+			}
+		})
+	}
+
+	for _, agent := range []*models.User{first, second} {
+		current, err := service.GetUserByID(ctx, agent.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if current.ManagerID != nil {
+			t.Errorf("%s yöneticisiz kalmalıydı: %v", agent.Account, *current.ManagerID)
+		}
+	}
+}
+
+func TestDeleteManagerUnassignsAgents(t *testing.T) {
+	ctx := testdb.Organization(t)
+	admin := testdb.User(t, ctx, models.User{Account: "admin@test", Type: models.Dashboard})
+	manager := testdb.User(t, ctx, models.User{Account: "manager@test", Type: models.Dashboard})
+	agent := testdb.User(t, ctx, models.User{Account: "agent@test", ManagerID: &manager.ID})
+
+	if err := services.NewUserService().DeleteUser(testdb.As(ctx, admin.ID), manager.ID); err != nil {
+		t.Fatalf("yönetici silinemedi: %v", err)
+	}
+
+	current, err := services.NewUserService().GetUserByID(ctx, agent.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if current.ManagerID != nil {
+		t.Fatalf("silinen yöneticinin aracısı serbest bırakılmadı: %v", *current.ManagerID)
+	}
+
+	var count int64
+	err = configs.GetDB().Model(&models.AuditLog{}).
+		Where("entity_type = ? AND entity_id = ? AND actor_id = ?", "users", agent.ID, admin.ID).
+		Count(&count).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Fatalf("aracı için 1 denetim kaydı bekleniyordu, bulunan: %d", count)
+	}
+}
@@ -3,8 +3,12 @@ package services
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strconv"
 	"zatrano/models"
+	"zatrano/pkg/domainerrors"
 	"zatrano/pkg/logs"
+	"zatrano/pkg/ownership"
 	"zatrano/pkg/queryparams"
 	"zatrano/repositories"
 
@@ -13,86 +17,151 @@ import (
 
 const contextUserIDKey = "user_id"
 
+const (
+	OwnerFilterMine       = "mine"
+	OwnerFilterUnassigned = "none"
+)
+
+var (
+	ErrCannotDeactivateSelf = domainerrors.Forbidden("kendi hesabınızı pasif hale getiremezsiniz")
+	ErrCannotDemoteSelf     = domainerrors.Forbidden("kendi hesabınızın yönetici yetkisini kaldıramazsınız")
+	ErrCannotDeleteSelf     = domainerrors.Forbidden("kendi hesabınızı silemezsiniz")
+	ErrLastActiveDashboard  = domainerrors.Forbidden("sistemde en az bir aktif yönetici hesabı kalmalıdır")
+	ErrProtectedUser        = domainerrors.Forbidden("bu kullanıcı korumalıdır; pasif hale getirilemez, yetkisi değiştirilemez ve silinemez")
+	ErrOwnAgentsOnly        = domainerrors.Forbidden("yalnızca kendi aracılarınızı yönetebilirsiniz")
+	ErrInvalidManager       = domainerrors.Validation("seçilen yönetici bulunamadı").WithField("manager_id")
+	ErrNoUsersSelected      = domainerrors.Validation("en az bir kullanıcı seçmelisiniz").WithField("user_ids")
+	ErrUserVersionConflict  = domainerrors.Conflict("kullanıcı siz düzenlerken başka bir yönetici tarafından güncellendi")
+)
+
+type UserVersionConflictError struct {
+	Current *models.User
+}
+
+func (e *UserVersionConflictError) Error() string {
+	return ErrUserVersionConflict.Error()
+}
+
+func (e *UserVersionConflictError) Unwrap() error {
+	return ErrUserVersionConflict
+}
+
 type IUserService interface {
-	GetAllUsers(params queryparams.ListParams) (*queryparams.PaginatedResult, error)
-	GetUserByID(id uint) (*models.User, error)
+	GetAllUsers(ctx context.Context, params queryparams.ListParams, owner string) (*queryparams.PaginatedResult, error)
+	GetUserByID(ctx context.Context, id uint) (*models.User, error)
+	GetManagers(ctx context.Context) ([]models.User, error)
+	ReassignAgents(ctx context.Context, userIDs []uint, managerID uint) (int64, error)
 	CreateUser(ctx context.Context, user *models.User) error
 	UpdateUser(ctx context.Context, id uint, userData *models.User) error
 	DeleteUser(ctx context.Context, id uint) error
-	GetUserCount() (int64, error)
+	GetUserCount(ctx context.Context) (int64, error)
 }
 
 type UserService struct {
 	repo repositories.IUserRepository
+	crud *CRUDService[models.User]
+	jobs IJobService
 }
 
 func NewUserService() IUserService {
-	return &UserService{repo: repositories.NewUserRepository()}
+	repo := repositories.NewUserRepository()
+	return &UserService{
+		repo: repo,
+		crud: NewCRUDService[models.User](repo, "kullanıcı"),
+		jobs: NewJobService(),
+	}
 }
 
-func (s *UserService) GetAllUsers(params queryparams.ListParams) (*queryparams.PaginatedResult, error) {
-	if params.Page <= 0 {
-		params.Page = queryparams.DefaultPage
-	}
-	if params.PerPage <= 0 {
-		params.PerPage = queryparams.DefaultPerPage
-	} else if params.PerPage > queryparams.MaxPerPage {
-		logs.Log.Warn("Sayfa başına istenen kayıt sayısı limiti aştı, varsayılana çekildi.",
-			zap.Int("requested", params.PerPage),
-			zap.Int("max", queryparams.MaxPerPage),
-			zap.Int("default", queryparams.DefaultPerPage),
-		)
-		params.PerPage = queryparams.DefaultPerPage
-	}
-	if params.SortBy == "" {
-		params.SortBy = queryparams.DefaultSortBy
+func (s *UserService) GetAllUsers(ctx context.Context, params queryparams.ListParams, owner string) (*queryparams.PaginatedResult, error) {
+	switch owner {
+	case "":
+		return s.crud.GetAll(ctx, params)
+	case OwnerFilterMine:
+		currentUserID, _ := ctx.Value(contextUserIDKey).(uint)
+		return s.crud.GetAll(ctx, params, repositories.UsersManagedBy(currentUserID))
+	case OwnerFilterUnassigned:
+		return s.crud.GetAll(ctx, params, repositories.UsersWithoutManager())
 	}
-	if params.OrderBy == "" {
-		params.OrderBy = queryparams.DefaultOrderBy
+
+	managerID, err := strconv.ParseUint(owner, 10, 64)
+	if err != nil || managerID == 0 {
+		logs.Log.Warn("Geçersiz sahip filtresi yok sayıldı", zap.String("owner", owner))
+		return s.crud.GetAll(ctx, params)
 	}
+	return s.crud.GetAll(ctx, params, repositories.UsersManagedBy(uint(managerID)))
+}
+
+func (s *UserService) GetUserByID(ctx context.Context, id uint) (*models.User, error) {
+	return s.crud.GetByID(ctx, id)
+}
 
-	users, totalCount, err := s.repo.GetAll(params)
+func (s *UserService) GetManagers(ctx context.Context) ([]models.User, error) {
+	managers, err := s.repo.GetManagers(ctx)
 	if err != nil {
-		logs.Log.Error("GetAllUsersPaginated: Repository hatası", zap.Error(err))
-		return nil, errors.New("kullanıcılar getirilirken bir hata oluştu")
+		return nil, domainerrors.Internal("yönetici listesi alınamadı", err)
 	}
+	return managers, nil
+}
 
-	totalPages := queryparams.CalculateTotalPages(totalCount, params.PerPage)
-
-	result := &queryparams.PaginatedResult{
-		Data: users,
-		Meta: queryparams.PaginationMeta{
-			CurrentPage: params.Page,
-			PerPage:     params.PerPage,
-			TotalItems:  totalCount,
-			TotalPages:  totalPages,
-		},
+func (s *UserService) ReassignAgents(ctx context.Context, userIDs []uint, managerID uint) (int64, error) {
+	currentUserID, ok := ctx.Value(contextUserIDKey).(uint)
+	if !ok || currentUserID == 0 {
+		return 0, domainerrors.Forbidden("işlemi yapan kullanıcı kimliği context içinde bulunamadı")
+	}
+	if _, restricted := ownership.ManagerID(ctx); restricted {
+		logs.Log.Warn("Kısıtlı yönetici toplu sahip değişikliği denedi", zap.Uint("user_id", currentUserID))
+		return 0, ErrOwnAgentsOnly
+	}
+	if len(userIDs) == 0 {
+		return 0, ErrNoUsersSelected
 	}
 
-	return result, nil
-}
+	var newManagerID *uint
+	if managerID != 0 {
+		if err := s.ensureManager(ctx, managerID, 0); err != nil {
+			return 0, err
+		}
+		newManagerID = &managerID
+	}
 
-func (s *UserService) GetUserByID(id uint) (*models.User, error) {
-	user, err := s.repo.GetByID(id)
+	affected, err := s.repo.ReassignManager(ctx, userIDs, newManagerID, currentUserID)
 	if err != nil {
-		if errors.Is(err, errors.New("kayıt bulunamadı")) {
-			logs.Log.Warn("Kullanıcı bulunamadı (ID ile arama)", zap.Uint("user_id", id))
-			return nil, errors.New("kullanıcı bulunamadı")
+		logs.Log.Error("Toplu sahip değişikliği başarısız", zap.Uints("user_ids", userIDs), zap.Uint("manager_id", managerID), zap.Error(err))
+		if domainerrors.KindOf(err) != domainerrors.KindInternal {
+			return 0, err
+		}
+		return 0, domainerrors.Internal("kullanıcıların sahibi değiştirilemedi", err)
+	}
+
+	if affected > 0 && newManagerID != nil && managerID != currentUserID {
+		err := enqueueNotification(ctx, s.jobs, NotificationJobPayload{UserID: managerID, Message: NotificationMessage{
+			Title: "Size yeni aracılar atandı",
+			Body:  fmt.Sprintf("%d aracının sorumluluğu size devredildi.", affected),
+			Link:  "/dashboard/users?owner=" + OwnerFilterMine,
+			Level: models.NotificationInfo,
+		}})
+		if err != nil {
+			logs.Log.Warn("Aracı atama bildirimi gönderilemedi", zap.Uint("manager_id", managerID), zap.Error(err))
 		}
-		logs.Log.Error("Kullanıcı alınırken hata oluştu (ID ile arama)", zap.Uint("user_id", id), zap.Error(err))
-		return nil, errors.New("kullanıcı bilgileri alınırken bir veritabanı hatası oluştu")
 	}
-	return user, nil
+
+	InvalidateStatsCache()
+	logs.SLog.Infof("%d kullanıcının sahibi değiştirildi (yeni yönetici ID: %d)", affected, managerID)
+	return affected, nil
 }
 
 func (s *UserService) CreateUser(ctx context.Context, user *models.User) error {
 	if user.Password == "" {
-		return errors.New("şifre alanı boş olamaz")
+		return domainerrors.Validation("şifre alanı boş olamaz").WithField("password")
+	}
+
+	if err := s.applyOwnership(ctx, 0, user); err != nil {
+		return err
 	}
 
 	if err := user.SetPassword(user.Password); err != nil {
 		logs.Log.Error("Kullanıcı oluşturma: Şifre ayarlanamadı/hashlenemedi (SetPassword)", zap.String("account", user.Account), zap.Error(err))
-		return errors.New("şifre oluşturulurken bir hata oluştu")
+		return domainerrors.Internal("şifre oluşturulurken bir hata oluştu", err)
 	}
 
 	logs.Log.Info("Kullanıcı oluşturuluyor...",
@@ -106,9 +175,13 @@ func (s *UserService) CreateUser(ctx context.Context, user *models.User) error {
 			zap.String("account", user.Account),
 			zap.Error(err),
 		)
-		return errors.New("kullanıcı veritabanına kaydedilemedi")
+		if domainerrors.KindOf(err) != domainerrors.KindInternal {
+			return err
+		}
+		return domainerrors.Internal("kullanıcı veritabanına kaydedilemedi", err)
 	}
 
+	InvalidateStatsCache()
 	logs.SLog.Infof("Kullanıcı başarıyla oluşturuldu: %s (ID: %d)", user.Account, user.ID)
 	return nil
 }
@@ -118,24 +191,54 @@ func (s *UserService) UpdateUser(ctx context.Context, id uint, userData *models.
 	currentUserID, ok := userIDValue.(uint)
 	if !ok || currentUserID == 0 {
 		logs.Log.Error("UpdateUser: Context'te geçerli user_id bulunamadı veya 0.", zap.Any("value", userIDValue))
-		return errors.New("işlemi yapan kullanıcı kimliği context içinde bulunamadı")
+		return domainerrors.Forbidden("işlemi yapan kullanıcı kimliği context içinde bulunamadı")
 	}
 
-	_, err := s.repo.GetByID(id)
+	existingUser, err := s.repo.GetByID(ctx, id)
 	if err != nil {
-		if errors.Is(err, errors.New("kayıt bulunamadı")) {
+		if domainerrors.IsNotFound(err) {
 			logs.Log.Warn("Kullanıcı güncellenemedi: Kullanıcı bulunamadı (ön kontrol)", zap.Uint("user_id", id))
-			return errors.New("kullanıcı bulunamadı")
+			return domainerrors.WithMessage(err, "kullanıcı bulunamadı")
 		}
 		logs.Log.Error("Kullanıcı güncellenemedi: Kullanıcı aranırken hata (ön kontrol)", zap.Uint("user_id", id), zap.Error(err))
-		return errors.New("kullanıcı güncellenirken bir veritabanı hatası oluştu (ön kontrol)")
+		return domainerrors.Internal("kullanıcı güncellenirken bir veritabanı hatası oluştu (ön kontrol)", err)
+	}
+
+	if userData.Version == 0 {
+		logs.Log.Warn("Kullanıcı güncellenemedi: Sürüm bilgisi gönderilmedi", zap.Uint("user_id", id))
+		return repositories.ErrVersionRequired
+	}
+	if existingUser.Version != userData.Version {
+		logs.Log.Warn("Kullanıcı güncellenemedi: Sürüm çakışması (ön kontrol)",
+			zap.Uint("user_id", id),
+			zap.Uint("submitted_version", userData.Version),
+			zap.Uint("current_version", existingUser.Version),
+		)
+		return &UserVersionConflictError{Current: existingUser}
+	}
+
+	if err := s.applyOwnership(ctx, id, userData); err != nil {
+		return err
+	}
+
+	if err := s.checkUpdateInvariants(ctx, currentUserID, existingUser, userData); err != nil {
+		logs.Log.Warn("Kullanıcı güncellenemedi: Güvenlik kuralı ihlali",
+			zap.Uint("target_user_id", id),
+			zap.Uint("updated_by_user_id", currentUserID),
+			zap.Error(err),
+		)
+		return err
 	}
 
 	updateData := map[string]interface{}{
-		"name":    userData.Name,
-		"account": userData.Account,
-		"status":  userData.Status,
-		"type":    userData.Type,
+		"name":            userData.Name,
+		"account":         userData.Account,
+		"status":          userData.Status,
+		"type":            userData.Type,
+		"email":           userData.Email,
+		"phone":           userData.Phone,
+		"manager_id":      userData.ManagerID,
+		"own_agents_only": userData.OwnAgentsOnly,
 	}
 
 	passwordUpdated := false
@@ -143,7 +246,7 @@ func (s *UserService) UpdateUser(ctx context.Context, id uint, userData *models.
 		tempUserForHash := models.User{}
 		if err := tempUserForHash.SetPassword(userData.Password); err != nil {
 			logs.Log.Error("Kullanıcı güncelleme: Şifre ayarlanamadı/hashlenemedi (SetPassword)", zap.Uint("user_id", id), zap.Error(err))
-			return errors.New("şifre oluşturulurken bir hata oluştu")
+			return domainerrors.Internal("şifre oluşturulurken bir hata oluştu", err)
 		}
 		updateData["password"] = tempUserForHash.Password
 		passwordUpdated = true
@@ -156,18 +259,57 @@ func (s *UserService) UpdateUser(ctx context.Context, id uint, userData *models.
 		zap.Uint("updated_by_user_id", currentUserID),
 	)
 
-	err = s.repo.Update(ctx, id, updateData, currentUserID)
+	err = s.repo.Transaction(ctx, func(repo repositories.IUserRepository) error {
+		if removesActiveDashboard(existingUser, userData) {
+			if err := s.ensureAnotherActiveDashboard(ctx, repo, existingUser); err != nil {
+				return err
+			}
+		}
+		if err := repo.Update(ctx, id, userData.Version, updateData, currentUserID); err != nil {
+			return err
+		}
+		if existingUser.Type == models.Dashboard && userData.Type != models.Dashboard {
+			return repo.UnassignAgents(ctx, id)
+		}
+		return nil
+	})
 	if err != nil {
+		if errors.Is(err, repositories.ErrVersionConflict) {
+			currentUser, getErr := s.repo.GetByID(ctx, id)
+			if getErr != nil {
+				logs.Log.Error("Sürüm çakışması sonrası güncel kullanıcı alınamadı", zap.Uint("user_id", id), zap.Error(getErr))
+				return domainerrors.Internal("kullanıcı veritabanında güncellenemedi", getErr)
+			}
+			return &UserVersionConflictError{Current: currentUser}
+		}
+		if errors.Is(err, ErrLastActiveDashboard) {
+			return err
+		}
 		logs.Log.Error("Kullanıcı güncellenirken repository hatası",
 			zap.Uint("user_id", id),
 			zap.Error(err),
 		)
-		if errors.Is(err, errors.New("kayıt bulunamadı")) {
-			return errors.New("kullanıcı bulunamadı")
+		switch domainerrors.KindOf(err) {
+		case domainerrors.KindNotFound:
+			return domainerrors.WithMessage(err, "kullanıcı bulunamadı")
+		case domainerrors.KindInternal:
+			return domainerrors.Internal("kullanıcı veritabanında güncellenemedi", err)
 		}
-		return errors.New("kullanıcı veritabanında güncellenemedi")
+		return err
 	}
 
+	InvalidateStatsCache()
+	if passwordUpdated && currentUserID != id {
+		err := enqueueNotification(ctx, s.jobs, NotificationJobPayload{UserID: id, Message: NotificationMessage{
+			Title: "Şifreniz değiştirildi",
+			Body:  "Hesabınızın şifresi bir yönetici tarafından değiştirildi. Bu işlemden haberiniz yoksa yöneticinizle iletişime geçin.",
+			Link:  "/auth/profile",
+			Level: models.NotificationWarning,
+		}})
+		if err != nil {
+			logs.Log.Warn("Şifre değişikliği bildirimi gönderilemedi", zap.Uint("user_id", id), zap.Error(err))
+		}
+	}
 	logs.SLog.Infof("Kullanıcı başarıyla güncellendi (map ile): ID %d, Hesap: %s", id, userData.Account)
 	return nil
 }
@@ -175,26 +317,138 @@ func (s *UserService) UpdateUser(ctx context.Context, id uint, userData *models.
 func (s *UserService) DeleteUser(ctx context.Context, id uint) error {
 	logs.Log.Info("Kullanıcı siliniyor...", zap.Uint("user_id", id))
 
-	err := s.repo.Delete(ctx, id)
+	currentUserID, _ := ctx.Value(contextUserIDKey).(uint)
+	if currentUserID != 0 && currentUserID == id {
+		logs.Log.Warn("Kullanıcı silinemedi: Kendi hesabını silme girişimi", zap.Uint("user_id", id))
+		return ErrCannotDeleteSelf
+	}
+
+	existingUser, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if domainerrors.IsNotFound(err) {
+			logs.Log.Warn("Kullanıcı silinemedi: Kullanıcı bulunamadı (ön kontrol)", zap.Uint("user_id", id))
+			return domainerrors.WithMessage(err, "kullanıcı bulunamadı")
+		}
+		logs.Log.Error("Kullanıcı silinemedi: Kullanıcı aranırken hata (ön kontrol)", zap.Uint("user_id", id), zap.Error(err))
+		return domainerrors.Internal("kullanıcı silinirken bir veritabanı hatası oluştu (ön kontrol)", err)
+	}
+	if existingUser.Protected {
+		logs.Log.Warn("Kullanıcı silinemedi: Korumalı kullanıcı", zap.Uint("user_id", id))
+		return ErrProtectedUser
+	}
+
+	err = s.repo.Transaction(ctx, func(repo repositories.IUserRepository) error {
+		if err := s.ensureAnotherActiveDashboard(ctx, repo, existingUser); err != nil {
+			return err
+		}
+		if err := repo.Delete(ctx, id); err != nil {
+			return err
+		}
+		if existingUser.Type == models.Dashboard {
+			return repo.UnassignAgents(ctx, id)
+		}
+		return nil
+	})
 	if err != nil {
-		if errors.Is(err, errors.New("kayıt bulunamadı")) {
+		switch domainerrors.KindOf(err) {
+		case domainerrors.KindNotFound:
 			logs.Log.Warn("Kullanıcı silinemedi: Kullanıcı bulunamadı", zap.Uint("user_id", id))
-			return errors.New("kullanıcı bulunamadı")
+			return domainerrors.WithMessage(err, "kullanıcı bulunamadı")
+		case domainerrors.KindInternal:
+			logs.Log.Error("Kullanıcı silinirken repository hatası", zap.Uint("user_id", id), zap.Error(err))
+			return domainerrors.Internal("kullanıcı silinirken bir veritabanı hatası oluştu", err)
 		}
-		logs.Log.Error("Kullanıcı silinirken repository hatası", zap.Uint("user_id", id), zap.Error(err))
-		return errors.New("kullanıcı silinirken bir veritabanı hatası oluştu")
+		logs.Log.Warn("Kullanıcı silinemedi", zap.Uint("user_id", id), zap.Error(err))
+		return err
 	}
+	InvalidateStatsCache()
 	logs.SLog.Infof("Kullanıcı başarıyla silindi: ID %d", id)
 	return nil
 }
 
-func (s *UserService) GetUserCount() (int64, error) {
-	count, err := s.repo.GetCount()
+func (s *UserService) applyOwnership(ctx context.Context, targetID uint, user *models.User) error {
+	if managerID, restricted := ownership.ManagerID(ctx); restricted {
+		user.Type = models.Panel
+		user.ManagerID = &managerID
+		user.OwnAgentsOnly = false
+		return nil
+	}
+
+	if user.Type != models.Panel {
+		user.ManagerID = nil
+		return nil
+	}
+
+	user.OwnAgentsOnly = false
+	if user.ManagerID == nil {
+		return nil
+	}
+	return s.ensureManager(ctx, *user.ManagerID, targetID)
+}
+
+func (s *UserService) ensureManager(ctx context.Context, managerID uint, targetID uint) error {
+	if managerID == targetID {
+		return ErrInvalidManager
+	}
+	manager, err := s.repo.GetByID(ctx, managerID)
 	if err != nil {
-		logs.Log.Error("Kullanıcı sayısı alınırken hata oluştu", zap.Error(err))
-		return 0, errors.New("kullanıcı sayısı alınırken bir hata oluştu")
+		if domainerrors.IsNotFound(err) {
+			return ErrInvalidManager
+		}
+		return domainerrors.Internal("yönetici bilgileri alınamadı", err)
+	}
+	if manager.Type != models.Dashboard {
+		return ErrInvalidManager
 	}
-	return count, nil
+	return nil
+}
+
+func (s *UserService) checkUpdateInvariants(ctx context.Context, currentUserID uint, existing *models.User, userData *models.User) error {
+	deactivating := existing.Status && !userData.Status
+	demoting := existing.Type == models.Dashboard && userData.Type != models.Dashboard
+
+	if existing.ID == currentUserID {
+		if deactivating {
+			return ErrCannotDeactivateSelf
+		}
+		if demoting {
+			return ErrCannotDemoteSelf
+		}
+	}
+
+	if existing.Protected && (deactivating || demoting) {
+		return ErrProtectedUser
+	}
+	return nil
+}
+
+func removesActiveDashboard(existing *models.User, userData *models.User) bool {
+	return (existing.Status && !userData.Status) || (existing.Type == models.Dashboard && userData.Type != models.Dashboard)
+}
+
+func (s *UserService) ensureAnotherActiveDashboard(ctx context.Context, repo repositories.IUserRepository, target *models.User) error {
+	if target.Type != models.Dashboard || !target.Status {
+		return nil
+	}
+	activeIDs, err := repo.LockActiveIDsByType(ctx, models.Dashboard)
+	if err != nil {
+		return domainerrors.Internal("aktif yönetici sayısı kontrol edilemedi", err)
+	}
+	others := 0
+	for _, activeID := range activeIDs {
+		if activeID != target.ID {
+			others++
+		}
+	}
+	if others == 0 {
+		logs.Log.Warn("Son aktif yönetici hesabını kaldırma girişimi engellendi", zap.Uint("user_id", target.ID))
+		return ErrLastActiveDashboard
+	}
+	return nil
+}
+
+func (s *UserService) GetUserCount(ctx context.Context) (int64, error) {
+	return s.crud.GetCount(ctx)
 }
 
 var _ IUserService = (*UserService)(nil)
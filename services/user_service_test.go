@@ -0,0 +1,35 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	"zatrano/database/testdb"
+	"zatrano/models"
+	"zatrano/repositories"
+	"zatrano/services"
+)
+
+func TestUpdateUserRequiresVersion(t *testing.T) {
+	ctx := testdb.Organization(t)
+	admin := testdb.User(t, ctx, models.User{Account: "admin@test", Type: models.Dashboard})
+	agent := testdb.User(t, ctx, models.User{Account: "agent@test"})
+
+	update := *agent
+	update.Name = "Sürümsüz"
+	update.Version = 0
+	err := services.NewUserService().UpdateUser(testdb.As(ctx, admin.ID), agent.ID, &update)
+	if !errors.Is(err, repositories.ErrVersionRequired) {
+		t.Fatalf("sürümsüz güncelleme ErrVersionRequired dönmeli, dönen: %v", err)
+	}
+
+	update.Version = agent.Version + 1
+	var conflict *services.UserVersionConflictError
+	err = services.NewUserService().UpdateUser(testdb.As(ctx, admin.ID), agent.ID, &update)
+	if !errors.As(err, &conflict) || !errors.Is(err, services.ErrUserVersionConflict) {
+		t.Fatalf("farklı sürümle güncelleme sürüm çakışması dönmeli, dönen: %v", err)
+	}
+	if conflict.Current == nil || conflict.Current.Version != agent.Version {
+		t.Fatalf("çakışma hatası güncel kaydı taşımalı: %+v", conflict.Current)
+	}
+}